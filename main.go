@@ -1,6 +1,12 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 
@@ -11,20 +17,75 @@ import (
 var version = "dev"
 
 func main() {
+	dumpDefaults := flag.Bool("dump-defaults", false, "print the values enforced by the rules when not configured, as JSON, and exit")
+	flag.Parse()
+	if *dumpDefaults {
+		if err := writeRuleDefaults(os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "dumping the rule defaults: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		RuleSet: &tflint.BuiltinRuleSet{
 			Name:    "uw-kafka-config",
 			Version: version,
-			Rules: []tflint.Rule{
-				&rules.MSKModuleBackendRule{},
-				&rules.MSKAppTopicsRule{},
-				&rules.MSKTopicNameRule{},
-				&rules.MSKTopicConfigRule{},
-				&rules.MSKAppConsumeGroupsRule{},
-				// keep the comments rule after the config one, as the config one might remove some properties checked by the comments one
-				&rules.MSKTopicConfigCommentsRule{},
-				&rules.MSKUniqueAppNamesRule{},
-			},
+			Rules:   newRules(),
 		},
 	})
 }
+
+// newRules returns all the rules of the ruleset, in the order they run.
+func newRules() []tflint.Rule {
+	return []tflint.Rule{
+		&rules.MSKModuleBackendRule{},
+		&rules.MSKAppTopicsRule{},
+		&rules.MSKTopicNameRule{},
+		&rules.MSKTopicConfigRule{},
+		&rules.MSKAppConsumeGroupsRule{},
+		// keep the comments rule after the config one, as the config one might remove some properties checked by the comments one
+		&rules.MSKTopicConfigCommentsRule{},
+		&rules.MSKUniqueAppNamesRule{},
+		&rules.MSKAppCertNamespaceRule{},
+		&rules.MSKACLHostRule{},
+		&rules.MSKTopicJSONSyntaxRule{},
+		&rules.MSKAppRequiredAttributesRule{},
+		&rules.MSKAppProducedTopicConfigRule{},
+		&rules.MSKTopicConfigKeysRule{},
+		&rules.MSKTopicDeprecatedAttributesRule{},
+		&rules.MSKTopicFileTeamRule{},
+		&rules.MSKTopicConfigOrderRule{},
+		&rules.MSKAppUniqueConsumeGroupsRule{},
+		&rules.MSKTopicRetentionSegmentRule{},
+		&rules.MSKTopicNameDigitRule{},
+		&rules.MSKAppTopicReferencesRule{},
+		&rules.MSKTopicUniqueNamesRule{},
+		&rules.MSKTopicConfigCompressionFirstRule{},
+		&rules.MSKTopicUnusedRule{},
+		&rules.MSKAppProduceOwnedTopicsRule{},
+		&rules.MSKTopicTieredRetentionBytesRule{},
+		&rules.MSKAppTopicLoopRule{},
+		&rules.MSKModuleSourceRule{},
+		&rules.MSKTopicPartitionsRule{},
+		&rules.MSKTopicNameAliasesRule{},
+		&rules.MSKTopicConfigIndentRule{},
+		&rules.MSKTopicNameDomainRule{},
+		&rules.MSKTopicDocumentationRule{},
+		&rules.MSKAppConditionalTopicsRule{},
+		&rules.MSKPluginVersionRule{},
+		&rules.MSKTopicCompressionCommentRule{},
+		&rules.MSKTopicConfigStyleRule{},
+		&rules.MSKTopicTimestampTypeRule{},
+		&rules.MSKTopicRetentionBytesBudgetRule{},
+		&rules.MSKTopicFiniteRetentionRule{},
+		&rules.MSKModuleProviderRegionRule{},
+	}
+}
+
+// writeRuleDefaults writes the values enforced by the rules when not configured as JSON, by rule name.
+func writeRuleDefaults(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rules.RuleDefaults())
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+
+	"github.com/utilitywarehouse/tflint-ruleset-kafka-config/rules"
+)
+
+// childModuleRunner is a runner for a module called by the root module, like a shared topics module.
+type childModuleRunner struct {
+	*helper.Runner
+}
+
+func (r *childModuleRunner) GetModulePath() (addrs.Module, error) {
+	return addrs.Module{"topics"}, nil
+}
+
+// workDirRunner is a runner for a module in the kafka cluster config, as the topic names depend on its path.
+type workDirRunner struct {
+	*helper.Runner
+}
+
+func (r *workDirRunner) GetOriginalwd() (string, error) {
+	return filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"), nil
+}
+
+// Test_RulesSkipChildModules runs every rule on a child module with issues for several of them,
+// making sure they are only reported once, when linting the root module.
+func Test_RulesSkipChildModules(t *testing.T) {
+	files := map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "orders" {
+  name               = "Orders"
+  replication_factor = 6
+  partitions         = 1000
+  config = {
+    retention_ms       = "86400000"
+    "compression.type" = "gzip"
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "259200001" # keep data for 1 day
+  }
+}
+
+module "consumer" {
+  source         = "../modules/app"
+  consume_topics = ["external.topic", "external.topic"]
+}
+`,
+	}
+
+	for _, rule := range newRules() {
+		t.Run(rule.Name(), func(t *testing.T) {
+			runner := &childModuleRunner{Runner: helper.TestRunner(t, files)}
+			require.NoError(t, rule.Check(runner))
+
+			assert.Empty(t, runner.Runner.Issues)
+			assert.Empty(t, runner.Runner.Changes())
+		})
+	}
+}
+
+// Test_RulesIgnoreModulesWithoutKafkaResources runs every rule, except the backend one, on a module having only a backend,
+// making sure they don't fail nor report anything when there are no topics nor apps to check.
+func Test_RulesIgnoreModulesWithoutKafkaResources(t *testing.T) {
+	files := map[string]string{
+		"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk/pubsub/tfstate"
+    region = "eu-west-1"
+  }
+}
+`,
+	}
+
+	for _, rule := range newRules() {
+		if _, ok := rule.(*rules.MSKModuleBackendRule); ok {
+			continue
+		}
+		t.Run(rule.Name(), func(t *testing.T) {
+			runner := helper.TestRunner(t, files)
+			require.NoError(t, rule.Check(runner))
+
+			assert.Empty(t, runner.Issues)
+			assert.Empty(t, runner.Changes())
+		})
+	}
+}
+
+// Test_RulesFixesInSequence runs the enabled rules in order, applying the fixes of each rule before running the next one
+// like tflint does, making sure the comments inserted by the config rule are not inserted again by the comments one.
+func Test_RulesFixesInSequence(t *testing.T) {
+	files := map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "orders" {
+  name               = "pubsub.orders"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "259200000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}
+`,
+	}
+
+	for _, rule := range newRules() {
+		if !rule.Enabled() {
+			continue
+		}
+		runner := &workDirRunner{Runner: helper.TestRunner(t, files)}
+		require.NoError(t, rule.Check(runner), rule.Name())
+
+		for name, content := range runner.Runner.Changes() {
+			files[name] = string(content)
+		}
+	}
+
+	assert.Equal(t, `
+resource "kafka_topic" "orders" {
+  name               = "pubsub.orders"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200000" # keep data for 3 days
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}
+`, files["topics.tf"])
+}
+
+// Test_ConfigOrderRulesFixesAgree runs the fixes of the two opt-in rules ordering the config keys in sequence,
+// making sure the second one doesn't undo the first one.
+func Test_ConfigOrderRulesFixesAgree(t *testing.T) {
+	files := map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+  config = {
+    "retention.ms"     = "259200000"
+    "cleanup.policy"   = "delete"
+    "compression.type" = "zstd"
+  }
+}
+`,
+	}
+
+	orderRules := []tflint.Rule{&rules.MSKTopicConfigOrderRule{}, &rules.MSKTopicConfigCompressionFirstRule{}}
+	for _, rule := range orderRules {
+		runner := helper.TestRunner(t, files)
+		require.NoError(t, rule.Check(runner), rule.Name())
+
+		for name, content := range runner.Changes() {
+			files[name] = string(content)
+		}
+	}
+
+	assert.Equal(t, `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+  config = {
+    "compression.type" = "zstd"
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "259200000"
+  }
+}
+`, files["topics.tf"])
+
+	for _, rule := range orderRules {
+		runner := helper.TestRunner(t, files)
+		require.NoError(t, rule.Check(runner), rule.Name())
+
+		assert.Empty(t, runner.Issues, rule.Name())
+	}
+}
+
+func Test_WriteRuleDefaults(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeRuleDefaults(&out))
+
+	var defaults map[string]map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &defaults))
+
+	assert.Equal(t, float64(3), defaults["msk_topic_config"]["replication_factor"], "JSON numbers are decoded as float64")
+
+	ruleNames := map[string]struct{}{}
+	for _, rule := range newRules() {
+		ruleNames[rule.Name()] = struct{}{}
+	}
+	for name := range defaults {
+		assert.Contains(t, ruleNames, name, "defaults of an unregistered rule")
+	}
+}
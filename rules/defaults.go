@@ -0,0 +1,52 @@
+package rules
+
+import "maps"
+
+// RuleDefaults returns the values enforced by the rules when they are not configured, by rule name and then by
+// setting, named like the rule config attribute when it can be configured. It lets the tooling documenting the
+// rules discover them without parsing the source.
+func RuleDefaults() map[string]map[string]any {
+	return map[string]map[string]any{
+		(&MSKModuleBackendRule{}).Name(): {
+			"key_format":   defaultBackendKeyFormat,
+			"auth_methods": maps.Clone(defaultBackendAuthMethods),
+		},
+		(&MSKTopicNameRule{}).Name(): {
+			"separator":           defaultTopicNameSeparator,
+			"topic_resource_type": defaultTopicResourceType,
+		},
+		(&MSKTopicConfigRule{}).Name(): {
+			"replication_factor":            replicationFactorDefault,
+			"default_local_retention_days":  localRetentionTimeInDaysDefault,
+			"allowed_compression_types":     []string{compressionTypeVal},
+			"default_compression_type":      compressionTypeVal,
+			"cleanup_policy":                cleanupPolicyDefault,
+			"tiered_storage_threshold_days": tieredStorageThresholdInDaysDefault,
+		},
+		(&MSKTopicConfigCommentsRule{}).Name(): {
+			"byte_unit_style": string(byteUnitStyleIEC),
+		},
+		(&MSKTopicPartitionsRule{}).Name(): {
+			"min_partitions": minPartitionsDefault,
+			"max_partitions": maxPartitionsDefault,
+		},
+		(&MSKTopicDeprecatedAttributesRule{}).Name(): {
+			"deprecated_attributes": maps.Clone(deprecatedTopicAttributesDefault),
+		},
+		(&MSKTopicDocumentationRule{}).Name(): {
+			"comment_prefix": topicDocCommentPrefixDefault,
+		},
+		(&MSKTopicNameDomainRule{}).Name(): {
+			"depth": topicNameDomainDepthDefault,
+		},
+		(&MSKTopicRetentionBytesBudgetRule{}).Name(): {
+			"max_topic_bytes": maxTopicBytesDefault,
+		},
+		(&MSKTopicTimestampTypeRule{}).Name(): {
+			"topic_name_pattern": eventTopicNamePatternDefault,
+		},
+		(&MSKPluginVersionRule{}).Name(): {
+			"config_file": tflintConfigFileDefault,
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	aclHostAttrName = "acl_host"
+	aclHostWildcard = "*"
+	devEnv          = "dev"
+)
+
+// MSKACLHostRule checks that kafka ACLs outside the dev environment don't allow any host.
+type MSKACLHostRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKACLHostRule) Name() string {
+	return "msk_acl_host"
+}
+
+func (r *MSKACLHostRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKACLHostRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKACLHostRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKACLHostRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+
+	env, ok := envFromModulePath(modulePath)
+	if !ok {
+		logger.Debug("skipping module without env in its path", "path", modulePath)
+		return nil
+	}
+	if env == devEnv {
+		logger.Debug("skipping dev module")
+		return nil
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		"kafka_acl",
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: aclHostAttrName}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_acl contents: %w", err)
+	}
+
+	for _, aclResource := range resourceContents.Blocks {
+		hostAttr, hasHost := aclResource.Body.Attributes[aclHostAttrName]
+		if !hasHost {
+			continue
+		}
+
+		var host string
+		if err := runner.EvaluateExpr(hostAttr.Expr, &host, nil); err != nil {
+			return fmt.Errorf("decoding attribute '%s': %w", aclHostAttrName, err)
+		}
+		if host != aclHostWildcard {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"'%s' must not be the wildcard '%s' in the '%s' environment",
+				aclHostAttrName,
+				aclHostWildcard,
+				env,
+			),
+			hostAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: wildcard acl host: %w", err)
+		}
+	}
+
+	return nil
+}
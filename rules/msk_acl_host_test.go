@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKACLHostRule(t *testing.T) {
+	rule := &MSKACLHostRule{}
+
+	const wildcardACL = `
+resource "kafka_acl" "any_host" {
+  resource_name       = "pubsub.my-topic"
+  resource_type       = "Topic"
+  acl_principal       = "User:CN=pubsub/my-app"
+  acl_host            = "*"
+  acl_operation       = "Read"
+  acl_permission_type = "Allow"
+}
+`
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		workDir  string
+		expected helper.Issues
+	}{
+		{
+			name:    "wildcard host in prod",
+			workDir: filepath.Join("kafka-cluster-config", "prod-aws", "kafka-shared-msk", "pubsub"),
+			files:   map[string]string{"acls.tf": wildcardACL},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'acl_host' must not be the wildcard '*' in the 'prod' environment",
+					Range: hcl.Range{
+						Filename: "acls.tf",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			name:     "wildcard host in dev",
+			workDir:  filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files:    map[string]string{"acls.tf": wildcardACL},
+			expected: []*helper.Issue{},
+		},
+		{
+			name:    "specific host in prod",
+			workDir: filepath.Join("kafka-cluster-config", "prod-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"acls.tf": `
+resource "kafka_acl" "specific_host" {
+  resource_name = "pubsub.my-topic"
+  acl_host      = "10.0.0.1"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), tc.workDir)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const certCommonNameSepChar = "/"
+
+// MSKAppCertNamespaceRule checks whether the namespace of a TLS app's
+// cert_common_name matches the team owning the module.
+type MSKAppCertNamespaceRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppCertNamespaceRule) Name() string {
+	return "msk_app_cert_namespace"
+}
+
+func (r *MSKAppCertNamespaceRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKAppCertNamespaceRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppCertNamespaceRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKAppCertNamespaceRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+
+	tlsAppModules, err := getTLSAppModules(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, appModule := range tlsAppModules {
+		appNameAttr := appModule.Body.Attributes[commonNameAttribute]
+
+		var appName string
+		diags := gohcl.DecodeExpression(appNameAttr.Expr, nil, &appName)
+		if diags.HasErrors() {
+			return fmt.Errorf("decoding expression for attribute %s: %w", commonNameAttribute, diags)
+		}
+
+		namespace, _, hasNamespace := strings.Cut(appName, certCommonNameSepChar)
+		if !hasNamespace {
+			logger.Debug("skipping app without namespace", "name", appName)
+			continue
+		}
+
+		if namespace == teamName {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"the namespace of '%s' should match the team name '%s', but '%s' has namespace '%s'",
+				commonNameAttribute,
+				teamName,
+				appName,
+				namespace,
+			),
+			appNameAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: %w", err)
+		}
+	}
+
+	return nil
+}
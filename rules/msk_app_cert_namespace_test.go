@@ -0,0 +1,76 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppCertNamespaceRule(t *testing.T) {
+	rule := &MSKAppCertNamespaceRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "namespace doesn't match the team",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "other-team/my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "the namespace of 'cert_common_name' should match the team name 'pubsub', but 'other-team/my-app' has namespace 'other-team'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			name: "namespace matches the team",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "app name without namespace is ignored",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub")
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
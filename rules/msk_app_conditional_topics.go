@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKAppConditionalTopicsRule checks that the apps don't reference topics created conditionally,
+// like with 'count = var.enabled ? 1 : 0', which references break when the topic is not created.
+type MSKAppConditionalTopicsRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppConditionalTopicsRule) Name() string {
+	return "msk_app_conditional_topics"
+}
+
+func (r *MSKAppConditionalTopicsRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKAppConditionalTopicsRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppConditionalTopicsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKAppConditionalTopicsRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: countAttrName}}},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	conditionalTopics := map[string]struct{}{}
+	for _, topicResource := range resourceContents.Blocks {
+		countAttr, ok := topicResource.Body.Attributes[countAttrName]
+		if !ok {
+			continue
+		}
+		if _, ok := countAttr.Expr.(*hclsyntax.ConditionalExpr); ok {
+			conditionalTopics[topicResource.Labels[1]] = struct{}{}
+		}
+	}
+	if len(conditionalTopics) == 0 {
+		return nil
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{
+							{Name: "produce_topics"},
+							{Name: "consume_topics"},
+						},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	for _, block := range modules.Blocks {
+		for _, topicAttrName := range []string{"consume_topics", "produce_topics"} {
+			topicAttr, ok := block.Body.Attributes[topicAttrName]
+			if !ok {
+				continue
+			}
+			if err := r.reportConditionalTopics(runner, topicAttr, resourceType, conditionalTopics); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MSKAppConditionalTopicsRule) reportConditionalTopics(
+	runner tflint.Runner,
+	topicAttr *hclext.Attribute,
+	resourceType string,
+	conditionalTopics map[string]struct{},
+) error {
+	reported := map[string]struct{}{}
+	for _, traversal := range topicAttr.Expr.Variables() {
+		if traversal.RootName() != resourceType || len(traversal) < 2 {
+			continue
+		}
+		resourceAttr, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+		resourceName := resourceAttr.Name
+		if _, ok := conditionalTopics[resourceName]; !ok {
+			continue
+		}
+		if _, ok := reported[resourceName]; ok {
+			continue
+		}
+		reported[resourceName] = struct{}{}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"'%s' references the topic '%s.%s' created with a conditional count: it must be referenced like '%s.%s[0].name', "+
+					"which breaks when the topic is not created",
+				topicAttr.Name,
+				resourceType,
+				resourceName,
+				resourceType,
+				resourceName,
+			),
+			topicAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: conditional topic: %w", err)
+		}
+	}
+	return nil
+}
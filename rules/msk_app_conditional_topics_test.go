@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppConditionalTopicsRule(t *testing.T) {
+	rule := &MSKAppConditionalTopicsRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "referenced conditional topic",
+			input: `
+resource "kafka_topic" "orders" {
+  count = var.enabled ? 1 : 0
+  name  = "pubsub.orders"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.orders.name]
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "'consume_topics' references the topic 'kafka_topic.orders' created with a conditional count: it must be referenced like 'kafka_topic.orders[0].name', which breaks when the topic is not created",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			name: "referenced conditional topic by index",
+			input: `
+resource "kafka_topic" "orders" {
+  count = var.enabled ? 1 : 0
+  name  = "pubsub.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders[0].name, kafka_topic.orders[0].id]
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "'produce_topics' references the topic 'kafka_topic.orders' created with a conditional count: it must be referenced like 'kafka_topic.orders[0].name', which breaks when the topic is not created",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 74},
+					},
+				},
+			},
+		},
+		{
+			name: "unreferenced conditional topic",
+			input: `
+resource "kafka_topic" "orders" {
+  count = var.enabled ? 1 : 0
+  name  = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.invoices.name]
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "referenced topic with a static count",
+			input: `
+resource "kafka_topic" "orders" {
+  count = 1
+  name  = "pubsub.orders"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.orders[0].name]
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -2,8 +2,10 @@ package rules
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
@@ -14,6 +16,13 @@ const (
 	consumeGroupSepChar  = "."
 )
 
+// consumeGroupPrefixOptions match the consume groups against the team prefixes, independently of the topic names config.
+var consumeGroupPrefixOptions = topicPrefixOptions{separator: consumeGroupSepChar}
+
+type mskAppConsumeGroupsRuleConfig struct {
+	StrictTeamPrefix bool `hclext:"strict_team_prefix,optional"`
+}
+
 type MSKAppConsumeGroupsRule struct {
 	tflint.DefaultRule
 }
@@ -44,12 +53,35 @@ func (r *MSKAppConsumeGroupsRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	var ruleConfig mskAppConsumeGroupsRuleConfig
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
 	appBlocks, err := getTLSApps(runner)
 	if err != nil {
 		return err
 	}
 
-	return r.validateConsumeGroups(runner, appBlocks)
+	// the team aliases are configured on the topic name rule, keeping the groups consistent with the topics
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+
+	return r.validateConsumeGroups(
+		runner,
+		appBlocks,
+		teamName,
+		topicNameConfig.TeamAliases[teamName],
+		ruleConfig.StrictTeamPrefix,
+	)
 }
 
 func getTLSApps(runner tflint.Runner) (hclext.Blocks, error) {
@@ -86,7 +118,13 @@ func getTLSApps(runner tflint.Runner) (hclext.Blocks, error) {
 	return appBlocks, nil
 }
 
-func (r *MSKAppConsumeGroupsRule) validateConsumeGroups(runner tflint.Runner, appBlocks hclext.Blocks) error {
+func (r *MSKAppConsumeGroupsRule) validateConsumeGroups(
+	runner tflint.Runner,
+	appBlocks hclext.Blocks,
+	teamName string,
+	teamAliases []string,
+	strictTeamPrefix bool,
+) error {
 	for _, block := range appBlocks {
 		consumeGroupAttr := block.Body.Attributes[consumeGroupAttrName]
 
@@ -94,23 +132,64 @@ func (r *MSKAppConsumeGroupsRule) validateConsumeGroups(runner tflint.Runner, ap
 		if err := runner.EvaluateExpr(consumeGroupAttr.Expr, &consumeGroupNames, nil); err != nil {
 			return fmt.Errorf("decoding attribute '%s': %v", consumeGroupAttrName, err)
 		}
-		for _, name := range consumeGroupNames {
-			if !strings.Contains(name, consumeGroupSepChar) {
-				err := runner.EmitIssue(
-					r,
-					fmt.Sprintf(
-						"'%s' must be prefixed with the name of the team using it, but '%s' is not",
-						consumeGroupAttrName,
-						name,
-					),
-					consumeGroupAttr.Range,
+
+		// the list elements are fixed only when the groups are defined as a list literal
+		var groupExprs []hclsyntax.Expression
+		if listExpr, ok := consumeGroupAttr.Expr.(*hclsyntax.TupleConsExpr); ok &&
+			len(listExpr.Exprs) == len(consumeGroupNames) {
+			groupExprs = listExpr.Exprs
+		}
+
+		for i, name := range consumeGroupNames {
+			hasAliasPrefix := hasTeamNameOrAliasPrefix(name, teamName, teamAliases, consumeGroupPrefixOptions)
+			if hasTeamNameOrAliasPrefix(name, teamName, nil, consumeGroupPrefixOptions) ||
+				(hasAliasPrefix && !strictTeamPrefix) {
+				continue
+			}
+
+			msg := fmt.Sprintf(
+				"'%s' must be prefixed with the name of the team using it, but '%s' is not",
+				consumeGroupAttrName,
+				name,
+			)
+			prefixedName := teamName + consumeGroupSepChar + name
+			if hasAliasPrefix {
+				// strict mode: the alias is replaced by the team name taken from the module path
+				msg = fmt.Sprintf(
+					"'%s' must be prefixed with the team name '%s' taken from the module path, but '%s' is prefixed with an alias",
+					consumeGroupAttrName,
+					teamName,
+					name,
 				)
-				if err != nil {
+				_, groupName, _ := strings.Cut(name, consumeGroupSepChar)
+				prefixedName = teamName + consumeGroupSepChar + groupName
+			}
+
+			// a group with the prefix of another team is ambiguous: it could be a typo of the prefix, or a group to move
+			isOtherTeamPrefix := !hasAliasPrefix && strings.Contains(name, consumeGroupSepChar)
+			if groupExprs == nil || !isStringLiteral(groupExprs[i]) || isOtherTeamPrefix {
+				if err := runner.EmitIssue(r, msg, consumeGroupAttr.Range); err != nil {
 					return fmt.Errorf("emitting issue: %w", err)
 				}
+				continue
+			}
+
+			groupRange := groupExprs[i].Range()
+			err := runner.EmitIssueWithFix(r, msg, consumeGroupAttr.Range,
+				func(f tflint.Fixer) error {
+					return f.ReplaceText(groupRange, fmt.Sprintf("%q", prefixedName))
+				},
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue with fix: %w", err)
 			}
 		}
 	}
 
 	return nil
 }
+
+func isStringLiteral(expr hclsyntax.Expression) bool {
+	templateExpr, ok := expr.(*hclsyntax.TemplateExpr)
+	return ok && templateExpr.IsStringLiteral()
+}
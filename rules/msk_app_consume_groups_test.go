@@ -1,19 +1,23 @@
 package rules
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
 )
 
 func Test_MSKAppConsumeGroupsRule(t *testing.T) {
 	rule := &MSKAppConsumeGroupsRule{}
+	workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "my-team")
 
 	for _, tc := range []struct {
 		name     string
 		files    map[string]string
+		fixed    string
 		expected helper.Issues
 	}{
 		{
@@ -25,6 +29,11 @@ module "my-app" {
 }
 `,
 			},
+			fixed: `
+module "my-app" {
+  consume_groups = ["my-team.my-bad-group"]
+}
+`,
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
@@ -49,6 +58,14 @@ module "my-app" {
 }
 `,
 			},
+			fixed: `
+module "my-app" {
+  consume_groups = [
+    "my-team.my-bad-group1",
+    "my-team.my-bad-group2",
+  ]
+}
+`,
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
@@ -70,6 +87,127 @@ module "my-app" {
 				},
 			},
 		},
+		{
+			name: "group not a string literal",
+			files: map[string]string{
+				"file.tf": `
+module "my-app" {
+	consume_groups = ["${"my-bad-group"}"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_groups' must be prefixed with the name of the team using it, but 'my-bad-group' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			name: "group prefixed with another team",
+			files: map[string]string{
+				"file.tf": `
+module "my-app" {
+	consume_groups = ["random.group"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_groups' must be prefixed with the name of the team using it, but 'random.group' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			name: "group prefixed with a team alias",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled = true
+  team_aliases = {
+    my-team = ["my-alias"]
+  }
+}`,
+				"file.tf": `
+module "my-app" {
+	consume_groups = ["my-alias.group"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "group prefixed with a team alias in strict mode",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled = true
+  team_aliases = {
+    my-team = ["my-alias"]
+  }
+}
+
+rule "msk_app_consume_groups" {
+  enabled            = true
+  strict_team_prefix = true
+}`,
+				"file.tf": `
+module "my-app" {
+	consume_groups = ["my-alias.group"]
+}
+`,
+			},
+			fixed: `
+module "my-app" {
+  consume_groups = ["my-team.group"]
+}
+`,
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_groups' must be prefixed with the team name 'my-team' taken from the module path, but 'my-alias.group' is prefixed with an alias",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			name: "group prefixed with the team in strict mode",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled = true
+  team_aliases = {
+    my-team = ["my-alias"]
+  }
+}
+
+rule "msk_app_consume_groups" {
+  enabled            = true
+  strict_team_prefix = true
+}`,
+				"file.tf": `
+module "my-app" {
+	consume_groups = ["my-team.group"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
 		{
 			name: "no issue on valid names",
 			files: map[string]string{
@@ -83,11 +221,16 @@ module "my-app" {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			runner := helper.TestRunner(t, tc.files)
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
 
 			require.NoError(t, rule.Check(runner))
 
 			helper.AssertIssues(t, tc.expected, runner.Issues)
+			if tc.fixed != "" {
+				helper.AssertChanges(t, map[string]string{"file.tf": tc.fixed}, runner.Changes())
+			} else {
+				assert.Empty(t, runner.Changes())
+			}
 		})
 	}
 }
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MSKAppProduceOwnedTopicsRule checks that the apps of a module only produce to topics owned by the module team.
+type MSKAppProduceOwnedTopicsRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppProduceOwnedTopicsRule) Name() string {
+	return "msk_app_produce_owned_topics"
+}
+
+func (r *MSKAppProduceOwnedTopicsRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKAppProduceOwnedTopicsRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppProduceOwnedTopicsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKAppProduceOwnedTopicsRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	// the team aliases are configured on the topic name rule
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+	teamAliases := topicNameConfig.TeamAliases[teamName]
+	prefixOpts := topicNameConfig.prefixOptions()
+
+	resourceNameMap, _, dynamicTopics, err := getKafkaTopics(runner)
+	if err != nil {
+		return err
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{{Name: produceTopicsAttrName}},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+	evalCtx := buildTopicNameContext(resourceType, resourceNameMap, dynamicTopics)
+	for _, block := range modules.Blocks {
+		produceAttr, ok := block.Body.Attributes[produceTopicsAttrName]
+		if !ok {
+			continue
+		}
+
+		val, diags := produceAttr.Expr.Value(evalCtx)
+		if diags.HasErrors() {
+			logger.Debug("skipping topics referencing other values, like locals", "labels", block.Labels, "diags", diags.Error())
+			continue
+		}
+		if !val.IsKnown() || !val.CanIterateElements() {
+			continue
+		}
+		for _, v := range val.AsValueSlice() {
+			// the topics which can't be statically verified are reported by the msk_app_topics rule
+			if !v.IsKnown() || v.Type() != cty.String {
+				continue
+			}
+
+			topicName := v.AsString()
+			if hasTeamNameOrAliasPrefix(topicName, teamName, teamAliases, prefixOpts) {
+				continue
+			}
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf(
+					"app '%s' of team '%s' must only produce to topics owned by its team, but '%s' is not",
+					block.Labels[0],
+					teamName,
+					topicName,
+				),
+				produceAttr.Range,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: produced topic not owned: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppProduceOwnedTopicsRule(t *testing.T) {
+	rule := &MSKAppProduceOwnedTopicsRule{}
+
+	workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub")
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "producing to owned topics",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name, "pubsub.payments"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "producing to topics owned by a team alias",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled      = true
+  team_aliases = { "pubsub" = ["ps"] }
+}`,
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "ps.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "producing to foreign topics",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "other-team.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name, "pubsub.payments"]
+  consume_topics = ["another-team.events"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "app 'producer' of team 'pubsub' must only produce to topics owned by its team, but 'other-team.orders' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 64},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const produceTopicsAttrName = "produce_topics"
+
+// MSKAppProducedTopicConfigRule checks whether the topics produced by the apps
+// in a module define their configuration.
+type MSKAppProducedTopicConfigRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppProducedTopicConfigRule) Name() string {
+	return "msk_app_produced_topic_config"
+}
+
+func (r *MSKAppProducedTopicConfigRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKAppProducedTopicConfigRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppProducedTopicConfigRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKAppProducedTopicConfigRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: append([]hclext.AttributeSchema{{Name: "name"}, {Name: "config"}}, topicMetaArgsSchema...),
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	resourceNameMap := map[string]string{}
+	var dynamicTopics []string
+	topicsWithoutConfig := map[string]*hclext.Block{}
+	for _, topicResource := range resourceContents.Blocks {
+		resourceName := topicResource.Labels[1]
+		nameAttr, hasName := topicResource.Body.Attributes["name"]
+		if !hasName {
+			continue
+		}
+
+		var name string
+		diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &name)
+		if diags.HasErrors() {
+			if isDynamicTopic(topicResource) {
+				dynamicTopics = append(dynamicTopics, resourceName)
+				continue
+			}
+			return fmt.Errorf("decoding name for kafka_topic '%s': %w", resourceName, diags)
+		}
+		resourceNameMap[resourceName] = name
+
+		if _, hasConfig := topicResource.Body.Attributes["config"]; !hasConfig {
+			topicsWithoutConfig[name] = topicResource
+		}
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{{Name: produceTopicsAttrName}},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	evalCtx := buildTopicNameContext(resourceType, resourceNameMap, dynamicTopics)
+	reported := map[string]struct{}{}
+	for _, block := range modules.Blocks {
+		produceAttr, ok := block.Body.Attributes[produceTopicsAttrName]
+		if !ok {
+			continue
+		}
+
+		val, diags := produceAttr.Expr.Value(evalCtx)
+		if diags.HasErrors() {
+			logger.Debug("skipping topics referencing other values, like locals", "labels", block.Labels, "diags", diags.Error())
+			continue
+		}
+		if !val.IsKnown() {
+			continue
+		}
+		for _, v := range val.AsValueSlice() {
+			if !v.IsKnown() || v.Type() != cty.String {
+				continue
+			}
+
+			name := v.AsString()
+			topic, lacksConfig := topicsWithoutConfig[name]
+			if !lacksConfig {
+				continue
+			}
+			if _, ok := reported[name]; ok {
+				continue
+			}
+			reported[name] = struct{}{}
+
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf(
+					"topic '%s' is produced by module '%s' but doesn't define a config: it will use the broker defaults",
+					name,
+					block.Labels[0],
+				),
+				topic.DefRange,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: produced topic without config: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppProducedTopicConfigRule(t *testing.T) {
+	rule := &MSKAppProducedTopicConfigRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "produced topic without config",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "my_topic" {
+  name = "pubsub.my-topic"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.my_topic.name]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic 'pubsub.my-topic' is produced by module 'producer' but doesn't define a config: it will use the broker defaults",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			name: "produced topic with config",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "my_topic" {
+  name = "pubsub.my-topic"
+  config = {
+    "cleanup.policy" = "delete"
+  }
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.my_topic.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "consumed topic without config",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "my_topic" {
+  name = "pubsub.my-topic"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.my_topic.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
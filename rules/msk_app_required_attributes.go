@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+type mskAppRequiredAttributesRuleConfig struct {
+	RequiredModuleAttributes []string `hclext:"required_module_attributes,optional"`
+}
+
+// MSKAppRequiredAttributesRule checks whether TLS app modules define the configured attributes.
+type MSKAppRequiredAttributesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppRequiredAttributesRule) Name() string {
+	return "msk_app_required_attributes"
+}
+
+func (r *MSKAppRequiredAttributesRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKAppRequiredAttributesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppRequiredAttributesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKAppRequiredAttributesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	var config mskAppRequiredAttributesRuleConfig
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if len(config.RequiredModuleAttributes) == 0 {
+		logger.Debug("no required module attributes configured")
+		return nil
+	}
+
+	attrSchemas := []hclext.AttributeSchema{{Name: commonNameAttribute}}
+	for _, attrName := range config.RequiredModuleAttributes {
+		attrSchemas = append(attrSchemas, hclext.AttributeSchema{Name: attrName})
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body:       &hclext.BodySchema{Attributes: attrSchemas},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	for _, block := range modules.Blocks {
+		if _, ok := block.Body.Attributes[commonNameAttribute]; !ok {
+			logger.Debug("skipping block, not a tls app", "labels", block.Labels)
+			continue
+		}
+
+		for _, attrName := range config.RequiredModuleAttributes {
+			if _, ok := block.Body.Attributes[attrName]; ok {
+				continue
+			}
+
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("tls app module '%s' must define the attribute '%s'", block.Labels[0], attrName),
+				block.DefRange,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: missing required attribute: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppRequiredAttributesRule(t *testing.T) {
+	rule := &MSKAppRequiredAttributesRule{}
+
+	const config = `
+rule "msk_app_required_attributes" {
+  enabled                    = true
+  required_module_attributes = ["resource_limits", "replicas"]
+}`
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "missing required attributes",
+			files: map[string]string{
+				".tflint.hcl": config,
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+  replicas         = 2
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "tls app module 'my_app' must define the attribute 'resource_limits'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			name: "all required attributes defined",
+			files: map[string]string{
+				".tflint.hcl": config,
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+  replicas         = 2
+  resource_limits  = { cpu = "1" }
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "modules which are not tls apps are ignored",
+			files: map[string]string{
+				".tflint.hcl": config,
+				"file.tf": `
+module "other" {
+  source = "../../../modules/other"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "no required attributes configured",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MSKAppTopicLoopRule warns on apps consuming a topic owned by their team which they also produce to,
+// as processing their own messages can create loops.
+type MSKAppTopicLoopRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppTopicLoopRule) Name() string {
+	return "msk_app_topic_loop"
+}
+
+func (r *MSKAppTopicLoopRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKAppTopicLoopRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppTopicLoopRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKAppTopicLoopRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	// the team aliases are configured on the topic name rule
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+	teamAliases := topicNameConfig.TeamAliases[teamName]
+	prefixOpts := topicNameConfig.prefixOptions()
+
+	resourceNameMap, _, dynamicTopics, err := getKafkaTopics(runner)
+	if err != nil {
+		return err
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{
+							{Name: "produce_topics"},
+							{Name: "consume_topics"},
+						},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+	evalCtx := buildTopicNameContext(resourceType, resourceNameMap, dynamicTopics)
+	for _, block := range modules.Blocks {
+		produceAttr, hasProduce := block.Body.Attributes["produce_topics"]
+		consumeAttr, hasConsume := block.Body.Attributes["consume_topics"]
+		if !hasProduce || !hasConsume {
+			continue
+		}
+
+		producedTopics := map[string]struct{}{}
+		for _, topicName := range evaluateTopicNames(block, produceAttr, evalCtx) {
+			producedTopics[topicName] = struct{}{}
+		}
+
+		for _, topicName := range evaluateTopicNames(block, consumeAttr, evalCtx) {
+			if _, isProduced := producedTopics[topicName]; !isProduced {
+				continue
+			}
+			// consuming a topic of another team, like a reply topic, is not a loop of the app
+			if !hasTeamNameOrAliasPrefix(topicName, teamName, teamAliases, prefixOpts) {
+				continue
+			}
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf(
+					"app '%s' consumes the topic '%s' it also produces to: processing its own messages can create a loop",
+					block.Labels[0],
+					topicName,
+				),
+				consumeAttr.Range,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: app topic loop: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// evaluateTopicNames returns the topic names of a topic list which can be statically verified.
+func evaluateTopicNames(block *hclext.Block, topicAttr *hclext.Attribute, evalCtx *hcl.EvalContext) []string {
+	val, diags := topicAttr.Expr.Value(evalCtx)
+	if diags.HasErrors() {
+		logger.Debug("skipping topics referencing other values, like locals", "labels", block.Labels, "diags", diags.Error())
+		return nil
+	}
+	if !val.IsKnown() || !val.CanIterateElements() {
+		return nil
+	}
+
+	var topicNames []string
+	for _, v := range val.AsValueSlice() {
+		if !v.IsKnown() || v.Type() != cty.String {
+			continue
+		}
+		topicNames = append(topicNames, v.AsString())
+	}
+	return topicNames
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppTopicLoopRule(t *testing.T) {
+	rule := &MSKAppTopicLoopRule{}
+
+	workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub")
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "app consuming its own produced topic",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "processor" {
+  produce_topics = [kafka_topic.orders.name, "pubsub.payments"]
+  consume_topics = ["pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "app 'processor' consumes the topic 'pubsub.orders' it also produces to: processing its own messages can create a loop",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 8, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			name: "app consuming its own produced topic owned by a team alias",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled      = true
+  team_aliases = { "pubsub" = ["ps"] }
+}`,
+				"file.tf": `
+module "processor" {
+  produce_topics = ["ps.orders"]
+  consume_topics = ["ps.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "app 'processor' consumes the topic 'ps.orders' it also produces to: processing its own messages can create a loop",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			name: "app consuming and producing different topics",
+			files: map[string]string{
+				"file.tf": `
+module "processor" {
+  produce_topics = ["pubsub.payments"]
+  consume_topics = ["pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "app consuming and producing a topic of another team",
+			files: map[string]string{
+				"file.tf": `
+module "processor" {
+  produce_topics = ["other-team.orders"]
+  consume_topics = ["other-team.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "apps producing and consuming the same topic",
+			files: map[string]string{
+				"file.tf": `
+module "producer" {
+  produce_topics = ["pubsub.orders"]
+}
+
+module "consumer" {
+  consume_topics = ["pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const prodEnv = "prod"
+
+// MSKAppTopicReferencesRule checks that the topics produced or consumed by an app
+// and defined in the module are referenced through their kafka_topic resource, instead of hardcoding their names.
+// It reports errors in prod and warnings in the other environments.
+type MSKAppTopicReferencesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppTopicReferencesRule) Name() string {
+	return "msk_app_topic_references"
+}
+
+func (r *MSKAppTopicReferencesRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKAppTopicReferencesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppTopicReferencesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKAppTopicReferencesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+
+	env, ok := envFromModulePath(modulePath)
+	if !ok {
+		logger.Debug("skipping module without env in its path", "path", modulePath)
+		return nil
+	}
+	issueRule := withSeverity(r, tflint.WARNING)
+	if env == prodEnv {
+		issueRule = withSeverity(r, tflint.ERROR)
+	}
+
+	resourceNameMap, _, _, err := getKafkaTopics(runner)
+	if err != nil {
+		return err
+	}
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+	// topic_name -> reference to the topic name, like 'kafka_topic.my_topic.name'
+	topicReferences := make(map[string]string, len(resourceNameMap))
+	for resourceName, topicName := range resourceNameMap {
+		topicReferences[topicName] = fmt.Sprintf("%s.%s.name", resourceType, resourceName)
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{
+							{Name: "produce_topics"},
+							{Name: "consume_topics"},
+						},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	for _, block := range modules.Blocks {
+		for _, topicAttr := range []string{"consume_topics", "produce_topics"} {
+			if err := r.reportHardcodedTopics(runner, issueRule, topicAttr, block, topicReferences); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MSKAppTopicReferencesRule) reportHardcodedTopics(
+	runner tflint.Runner,
+	issueRule tflint.Rule,
+	attrName string,
+	block *hclext.Block,
+	topicReferences map[string]string,
+) error {
+	topicAttr, ok := block.Body.Attributes[attrName]
+	if !ok {
+		return nil
+	}
+
+	listExpr, ok := topicAttr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		logger.Debug("skipping topics not defined as a list", "labels", block.Labels, "attribute", attrName)
+		return nil
+	}
+
+	for _, topicExpr := range listExpr.Exprs {
+		if !isStringLiteral(topicExpr) {
+			continue
+		}
+		val, diags := topicExpr.Value(nil)
+		if diags.HasErrors() {
+			return fmt.Errorf("evaluating topic name: %w", diags)
+		}
+
+		topicName := val.AsString()
+		// topics not defined in the module are reported by the msk_app_topics rule
+		reference, ok := topicReferences[topicName]
+		if !ok {
+			continue
+		}
+
+		topicRange := topicExpr.Range()
+		err := runner.EmitIssueWithFix(
+			issueRule,
+			fmt.Sprintf(
+				"'%s' must reference the topic '%s' through its resource: use '%s' instead of the hardcoded name",
+				attrName,
+				topicName,
+				reference,
+			),
+			topicRange,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(topicRange, reference)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: hardcoded topic name: %w", err)
+		}
+	}
+	return nil
+}
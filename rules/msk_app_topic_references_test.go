@@ -0,0 +1,111 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_MSKAppTopicReferencesRule(t *testing.T) {
+	rule := &MSKAppTopicReferencesRule{}
+
+	const hardcodedTopics = `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "producer" {
+  produce_topics = ["pubsub.orders", "other.payments"]
+  consume_topics = [kafka_topic.orders.name]
+}
+`
+	const fixedTopics = `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name, "other.payments"]
+  consume_topics = [kafka_topic.orders.name]
+}
+`
+	hardcodedTopicRange := hcl.Range{
+		Filename: "main.tf",
+		Start:    hcl.Pos{Line: 7, Column: 21},
+		End:      hcl.Pos{Line: 7, Column: 36},
+	}
+	const hardcodedTopicMsg = "'produce_topics' must reference the topic 'pubsub.orders' through its resource: " +
+		"use 'kafka_topic.orders.name' instead of the hardcoded name"
+
+	for _, tc := range []struct {
+		name     string
+		workDir  string
+		files    map[string]string
+		fixed    string
+		expected helper.Issues
+	}{
+		{
+			name:    "hardcoded topic in prod",
+			workDir: filepath.Join("kafka-cluster-config", "prod-aws", "kafka-shared-msk", "pubsub"),
+			files:   map[string]string{"main.tf": hardcodedTopics},
+			fixed:   fixedTopics,
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.ERROR),
+					Message: hardcodedTopicMsg,
+					Range:   hardcodedTopicRange,
+				},
+			},
+		},
+		{
+			name:    "hardcoded topic in dev",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files:   map[string]string{"main.tf": hardcodedTopics},
+			fixed:   fixedTopics,
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: hardcodedTopicMsg,
+					Range:   hardcodedTopicRange,
+				},
+			},
+		},
+		{
+			name:    "referenced topics",
+			workDir: filepath.Join("kafka-cluster-config", "prod-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"main.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), tc.workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Runner.Issues)
+			for i, issue := range runner.Runner.Issues {
+				assert.Equal(t, tc.expected[i].Rule.Severity(), issue.Rule.Severity())
+			}
+
+			if tc.fixed == "" {
+				assert.Empty(t, runner.Runner.Changes())
+			} else {
+				assert.Equal(t, tc.fixed, string(runner.Runner.Changes()["main.tf"]))
+			}
+		})
+	}
+}
@@ -5,12 +5,17 @@ import (
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/zclconf/go-cty/cty"
 )
 
+type mskAppTopicsRuleConfig struct {
+	ReportProducedAndConsumed bool `hclext:"report_produced_and_consumed,optional"`
+}
+
 // MSKAppTopicsRule checks whether an MSK module only consumes from topics
 // defined in the module.
 type MSKAppTopicsRule struct {
@@ -43,13 +48,18 @@ func (r *MSKAppTopicsRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	ruleConfig := mskAppTopicsRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
 	// resourceNameMap: resource_name -> topic_name (for mapping variables to EvalCtx)
 	// moduleTopics: topic_name -> struct{} (for name lookups)
-	resourceNameMap, moduleTopics, err := getKafkaTopics(runner)
+	resourceNameMap, moduleTopics, dynamicTopics, err := getKafkaTopics(runner)
 	if err != nil {
 		return err
 	}
-	logger.Debug("found topics", "topics", resourceNameMap)
+	logger.Debug("found topics", "topics", resourceNameMap, "dynamic_topics", dynamicTopics)
 
 	modules, err := runner.GetModuleContent(
 		&hclext.BodySchema{
@@ -71,10 +81,23 @@ func (r *MSKAppTopicsRule) Check(runner tflint.Runner) error {
 	if err != nil {
 		return fmt.Errorf("getting modules: %w", err)
 	}
-	evalCtx := buildTopicNameContext(resourceNameMap)
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+	evalCtx := buildTopicNameContext(resourceType, resourceNameMap, dynamicTopics)
 	for _, block := range modules.Blocks {
+		entriesByAttr := map[string][]topicEntry{}
 		for _, topicAttr := range []string{"consume_topics", "produce_topics"} {
-			if err := r.reportExternalTopics(runner, topicAttr, block, evalCtx, moduleTopics); err != nil {
+			entries, err := r.reportExternalTopics(runner, topicAttr, block, evalCtx, moduleTopics)
+			if err != nil {
+				return err
+			}
+			entriesByAttr[topicAttr] = entries
+		}
+
+		if ruleConfig.ReportProducedAndConsumed {
+			if err := r.reportProducedAndConsumedTopics(runner, block, entriesByAttr); err != nil {
 				return err
 			}
 		}
@@ -82,11 +105,98 @@ func (r *MSKAppTopicsRule) Check(runner tflint.Runner) error {
 	return nil
 }
 
-func getKafkaTopics(runner tflint.Runner) (map[string]string, map[string]struct{}, error) {
+/*
+reportProducedAndConsumedTopics reports with a warning the topics both produced and consumed by the same module.
+It is usually a mistake, or at least worth documenting, as the app consumes its own records.
+*/
+func (r *MSKAppTopicsRule) reportProducedAndConsumedTopics(
+	runner tflint.Runner,
+	block *hclext.Block,
+	entriesByAttr map[string][]topicEntry,
+) error {
+	produced := map[string]struct{}{}
+	for _, entry := range entriesByAttr["produce_topics"] {
+		produced[entry.name] = struct{}{}
+	}
+
+	reported := map[string]struct{}{}
+	for _, entry := range entriesByAttr["consume_topics"] {
+		if _, ok := produced[entry.name]; !ok {
+			continue
+		}
+		if _, ok := reported[entry.name]; ok {
+			continue
+		}
+		reported[entry.name] = struct{}{}
+
+		msg := fmt.Sprintf(
+			"module '%s' both produces and consumes the topic '%s': make sure it is intended and document it",
+			block.Labels[0],
+			entry.name,
+		)
+		err := runner.EmitIssue(withSeverity(r, tflint.WARNING), msg, block.Body.Attributes["consume_topics"].Range)
+		if err != nil {
+			return fmt.Errorf("emitting issue: produced and consumed topic: %w", err)
+		}
+	}
+	return nil
+}
+
+const (
+	forEachAttrName = "for_each"
+	countAttrName   = "count"
+)
+
+// topicMetaArgsSchema are the meta-arguments creating multiple topics from a single resource.
+var topicMetaArgsSchema = []hclext.AttributeSchema{{Name: forEachAttrName}, {Name: countAttrName}}
+
+// isDynamicTopic returns whether the topic resource is defined with for_each or count,
+// so its name, like 'each.value', can't be statically decoded.
+func isDynamicTopic(topic *hclext.Block) bool {
+	_, hasForEach := topic.Body.Attributes[forEachAttrName]
+	_, hasCount := topic.Body.Attributes[countAttrName]
+	return hasForEach || hasCount
+}
+
+/*
+getKafkaTopics returns the mapping from resource name to topic name, the topic names
+and the resource names of the topics defined with for_each or count, which names can't be decoded.
+*/
+func getKafkaTopics(runner tflint.Runner) (map[string]string, map[string]struct{}, []string, error) {
+	topics, dynamicTopics, err := getKafkaTopicNames(runner)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	resourceNameMap := map[string]string{}
+	topicNameMap := map[string]struct{}{}
+	for _, topic := range topics {
+		resourceNameMap[topic.resourceName] = topic.name
+		topicNameMap[topic.name] = struct{}{}
+	}
+
+	return resourceNameMap, topicNameMap, dynamicTopics, nil
+}
+
+type kafkaTopicName struct {
+	resourceName string
+	name         string
+	attr         *hclext.Attribute
+	defRange     hcl.Range
+}
+
+// getKafkaTopicNames returns the decoded topic names, in their definition order,
+// and the resource names of the topics defined with for_each or count.
+func getKafkaTopicNames(runner tflint.Runner) ([]kafkaTopicName, []string, error) {
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	resourceContents, err := runner.GetResourceContent(
-		"kafka_topic",
+		resourceType,
 		&hclext.BodySchema{
-			Attributes: []hclext.AttributeSchema{{Name: "name"}},
+			Attributes: append([]hclext.AttributeSchema{{Name: "name"}}, topicMetaArgsSchema...),
 		},
 		nil,
 	)
@@ -94,43 +204,64 @@ func getKafkaTopics(runner tflint.Runner) (map[string]string, map[string]struct{
 		return nil, nil, fmt.Errorf("getting kafka_topic contents: %w", err)
 	}
 
-	resourceNameMap := map[string]string{}
-	topicNameMap := map[string]struct{}{}
+	var topics []kafkaTopicName
+	var dynamicTopics []string
 	for _, topicResource := range resourceContents.Blocks {
 		resourceName := topicResource.Labels[1]
-		nameAttr := topicResource.Body.Attributes["name"]
+		nameAttr, ok := topicResource.Body.Attributes["name"]
+		if !ok {
+			// the missing name is reported by the msk_topic_name rule
+			continue
+		}
 
 		var name string
 		diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &name)
 		if diags.HasErrors() {
+			if isDynamicTopic(topicResource) {
+				dynamicTopics = append(dynamicTopics, resourceName)
+				continue
+			}
 			return nil, nil, fmt.Errorf(
 				"decoding name for kafka_topic '%s': %w",
 				resourceName,
 				diags,
 			)
 		}
-		resourceNameMap[resourceName] = name
-		topicNameMap[name] = struct{}{}
+		topics = append(topics, kafkaTopicName{
+			resourceName: resourceName,
+			name:         name,
+			attr:         nameAttr,
+			defRange:     topicResource.DefRange,
+		})
 	}
 
-	return resourceNameMap, topicNameMap, nil
+	return topics, dynamicTopics, nil
 }
 
-func buildTopicNameContext(topicNameMap map[string]string) *hcl.EvalContext {
+func buildTopicNameContext(
+	resourceType string,
+	topicNameMap map[string]string,
+	dynamicTopics []string,
+) *hcl.EvalContext {
 	// tflint doesn't do any variable expansion, so we manually build an
 	// EvalContext that we can use for lookups of variables like
 	// `kafka_topic.my_topic.name` via a lookup like:
 	// EvalContext.Variables["kafka_topic"]["my_topic"]["name"]
+	// The resource type is 'kafka_topic', unless configured otherwise.
 	nameMap := map[string]cty.Value{}
 	for topicResourceName, topicName := range topicNameMap {
 		nameMap[topicResourceName] = cty.ObjectVal(
 			map[string]cty.Value{"name": cty.StringVal(topicName)},
 		)
 	}
+	// the names of the topics defined with for_each or count are unknown
+	for _, topicResourceName := range dynamicTopics {
+		nameMap[topicResourceName] = cty.DynamicVal
+	}
 
 	return &hcl.EvalContext{
 		Variables: map[string]cty.Value{
-			"kafka_topic": cty.ObjectVal(nameMap),
+			resourceType: cty.ObjectVal(nameMap),
 		},
 	}
 }
@@ -141,18 +272,40 @@ func (r *MSKAppTopicsRule) reportExternalTopics(
 	block *hclext.Block,
 	evalCtx *hcl.EvalContext,
 	moduleTopicNames map[string]struct{},
-) error {
+) ([]topicEntry, error) {
 	topicAttr, ok := block.Body.Attributes[attrName]
 	if !ok {
 		logger.Debug("skipping block, doesn't provide producer/consumer", "labels", block.Labels)
-		return nil
+		return nil, nil
 	}
 
 	val, diags := topicAttr.Expr.Value(evalCtx)
 	if diags.HasErrors() {
-		return fmt.Errorf("evaluating topic names: %w", diags)
+		// the topics reference other values, like locals or variables, so let tflint evaluate them.
+		// The kafka_topic references it can't resolve are unknown and are skipped.
+		logger.Debug("evaluating topic names with the runner", "labels", block.Labels, "diags", diags.Error())
+		return r.reportExternalEvaluatedTopics(runner, attrName, topicAttr, moduleTopicNames)
+	}
+	if !val.IsKnown() {
+		return nil, r.reportDynamicTopics(runner, attrName, topicAttr)
+	}
+	values := val.AsValueSlice()
+	external := make([]bool, len(values))
+	for i, v := range values {
+		if v.IsKnown() && v.Type() == cty.String {
+			_, isModuleTopic := moduleTopicNames[v.AsString()]
+			external[i] = !isModuleTopic
+		}
 	}
-	for _, v := range val.AsValueSlice() {
+	removeRanges := topicListRemoveRanges(topicAttr, external)
+
+	entries := make([]topicEntry, 0, len(values))
+	hasDynamicTopics := false
+	for i, v := range values {
+		if !v.IsKnown() {
+			hasDynamicTopics = true
+			continue
+		}
 		if v.Type() != cty.String {
 			err := runner.EmitIssue(
 				r,
@@ -164,12 +317,108 @@ func (r *MSKAppTopicsRule) reportExternalTopics(
 				topicAttr.Range,
 			)
 			if err != nil {
-				return fmt.Errorf("emitting issue: %w", err)
+				return nil, fmt.Errorf("emitting issue: %w", err)
 			}
 			continue
 		}
 
 		name := v.AsString()
+		entries = append(entries, topicEntry{name: name, literal: isLiteralTopicEntry(topicAttr, i)})
+		if !external[i] {
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"'%s' may only contain topics defined in the current module but '%s' is not",
+			attrName,
+			name,
+		)
+		var err error
+		if removeRange, ok := removeRanges[i]; ok {
+			err = runner.EmitIssueWithFix(r, msg, topicAttr.Range, func(f tflint.Fixer) error {
+				return f.Remove(removeRange)
+			})
+		} else {
+			err = runner.EmitIssue(r, msg, topicAttr.Range)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("emitting issue: %w", err)
+		}
+	}
+	if hasDynamicTopics {
+		// reported once for all the dynamic topics of the attribute
+		if err := r.reportDynamicTopics(runner, attrName, topicAttr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.reportDuplicateTopics(runner, attrName, topicAttr, entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+/*
+topicListRemoveRanges returns the ranges removing the entries of a topics list literal, by their index,
+including their separator, so the remaining entries stay a valid list on a single line or on multiple lines.
+An entry is removed with the separator after it, or with the separator before it when only removed entries follow,
+so the ranges of the removed entries never overlap:
+
+	["a", "b", "c"] -> removing "a" and "c" removes `"a", ` and `, "c"`
+*/
+func topicListRemoveRanges(topicAttr *hclext.Attribute, removed []bool) map[int]hcl.Range {
+	tuple, ok := topicAttr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok || len(tuple.Exprs) != len(removed) {
+		return nil
+	}
+
+	// the entries from trailingIdx are all removed
+	trailingIdx := len(removed)
+	for trailingIdx > 0 && removed[trailingIdx-1] {
+		trailingIdx--
+	}
+
+	ranges := map[int]hcl.Range{}
+	for i, expr := range tuple.Exprs {
+		if !removed[i] {
+			continue
+		}
+
+		rng := expr.Range()
+		switch {
+		case i >= trailingIdx && trailingIdx > 0:
+			rng.Start = tuple.Exprs[i-1].Range().End
+		case i < len(tuple.Exprs)-1:
+			rng.End = tuple.Exprs[i+1].Range().Start
+		}
+		ranges[i] = rng
+	}
+	return ranges
+}
+
+func (r *MSKAppTopicsRule) reportExternalEvaluatedTopics(
+	runner tflint.Runner,
+	attrName string,
+	topicAttr *hclext.Attribute,
+	moduleTopicNames map[string]struct{},
+) ([]topicEntry, error) {
+	var val cty.Value
+	if err := runner.EvaluateExpr(topicAttr.Expr, &val, nil); err != nil {
+		logger.Debug("skipping topics which can't be evaluated", "attribute", attrName, "error", err)
+		return nil, nil
+	}
+	if !val.IsWhollyKnown() || !val.CanIterateElements() {
+		logger.Debug("skipping topics which can't be statically verified", "attribute", attrName)
+		return nil, nil
+	}
+
+	entries := make([]topicEntry, 0, val.LengthInt())
+	for i, v := range val.AsValueSlice() {
+		if v.Type() != cty.String {
+			continue
+		}
+		name := v.AsString()
+		entries = append(entries, topicEntry{name: name, literal: isLiteralTopicEntry(topicAttr, i)})
 		if _, ok := moduleTopicNames[name]; !ok {
 			err := runner.EmitIssue(
 				r,
@@ -181,10 +430,82 @@ func (r *MSKAppTopicsRule) reportExternalTopics(
 				topicAttr.Range,
 			)
 			if err != nil {
-				return fmt.Errorf("emitting issue: %w", err)
+				return nil, fmt.Errorf("emitting issue: %w", err)
 			}
 		}
 	}
+	if err := r.reportDuplicateTopics(runner, attrName, topicAttr, entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// topicEntry is a topic name listed in produce_topics or consume_topics.
+type topicEntry struct {
+	name string
+	// literal is whether the entry is a string, rather than a reference like 'kafka_topic.orders.name'
+	literal bool
+}
+
+/*
+reportDuplicateTopics reports with a warning the topics listed more than once in the attribute, usually a copy-paste error.
+The entries can be the same string or references resolving to the same name, like 'kafka_topic.orders.name'.
+*/
+func (r *MSKAppTopicsRule) reportDuplicateTopics(
+	runner tflint.Runner,
+	attrName string,
+	topicAttr *hclext.Attribute,
+	entries []topicEntry,
+) error {
+	counts := map[string]int{}
+	allLiteral := map[string]bool{}
+	var duplicates []string
+	for _, entry := range entries {
+		counts[entry.name]++
+		if counts[entry.name] == 1 {
+			allLiteral[entry.name] = entry.literal
+			continue
+		}
+		allLiteral[entry.name] = allLiteral[entry.name] && entry.literal
+		if counts[entry.name] == 2 {
+			duplicates = append(duplicates, entry.name)
+		}
+	}
 
+	for _, name := range duplicates {
+		msg := fmt.Sprintf("'%s' lists the topic '%s' more than once", attrName, name)
+		if !allLiteral[name] {
+			msg += ", through references resolving to the same name"
+		}
+		if err := runner.EmitIssue(withSeverity(r, tflint.WARNING), msg, topicAttr.Range); err != nil {
+			return fmt.Errorf("emitting issue: duplicate topics: %w", err)
+		}
+	}
+	return nil
+}
+
+// isLiteralTopicEntry returns whether the entry at the index of a topics list literal is a string.
+// The entries of other expressions, like 'concat(...)', are considered references.
+func isLiteralTopicEntry(topicAttr *hclext.Attribute, idx int) bool {
+	tuple, ok := topicAttr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok || idx >= len(tuple.Exprs) {
+		return false
+	}
+	tmpl, ok := tuple.Exprs[idx].(*hclsyntax.TemplateExpr)
+	return ok && tmpl.IsStringLiteral()
+}
+
+func (r *MSKAppTopicsRule) reportDynamicTopics(runner tflint.Runner, attrName string, topicAttr *hclext.Attribute) error {
+	err := runner.EmitIssue(
+		withSeverity(r, tflint.NOTICE),
+		fmt.Sprintf(
+			"'%s' references topics defined with for_each or count, which can't be statically verified",
+			attrName,
+		),
+		topicAttr.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: dynamic topics: %w", err)
+	}
 	return nil
 }
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_MSKAppTopicsRule(t *testing.T) {
@@ -15,6 +17,7 @@ func Test_MSKAppTopicsRule(t *testing.T) {
 		name     string
 		files    map[string]string
 		expected helper.Issues
+		fixed    string
 	}{
 		{
 			name: "consuming from topic not in module",
@@ -36,6 +39,56 @@ module "consumer" {
 					},
 				},
 			},
+			fixed: `
+module "consumer" {
+  consume_topics = []
+}
+`,
+		},
+		{
+			name: "consuming from topics in a local value",
+			files: map[string]string{
+				"file.tf": `
+locals {
+	topics = ["some_topic"]
+}
+
+module "consumer" {
+	consume_topics = local.topics
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "topics with a custom resource type",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+	enabled             = true
+	topic_resource_type = "uw_kafka_topic"
+}`,
+				"file.tf": `
+resource "uw_kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+module "consumer" {
+	consume_topics = [uw_kafka_topic.orders.name, "some_topic"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_topics' may only contain topics defined in the current module but 'some_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 61},
+					},
+				},
+			},
 		},
 		{
 			name: "producing from topic not in module",
@@ -181,6 +234,329 @@ module "consumer" {
 # other resources in the module should be ignored
 resource "some_resource" "some_other_resource" {
 }
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "consuming from topics defined with for_each",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "topics" {
+	for_each = toset(["pubsub.first", "pubsub.second"])
+	name     = each.value
+}
+
+resource "kafka_topic" "my_topic" {
+	name = "pubsub.my-topic"
+}
+
+module "consumer" {
+	consume_topics = [
+		kafka_topic.topics["pubsub.first"].name,
+		kafka_topic.topics["pubsub.second"].name,
+		kafka_topic.my_topic.name,
+	]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.NOTICE),
+					Message: "'consume_topics' references topics defined with for_each or count, which can't be statically verified",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 12, Column: 2},
+						End:      hcl.Pos{Line: 16, Column: 3},
+					},
+				},
+			},
+		},
+		{
+			name: "consuming the same topic twice",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+module "consumer" {
+	consume_topics = ["pubsub.orders", "pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: "'consume_topics' lists the topic 'pubsub.orders' more than once",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			name: "producing the same topic through a reference and its name",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+	name = "pubsub.invoices"
+}
+
+module "producer" {
+	produce_topics = [kafka_topic.orders.name, kafka_topic.invoices.name, "pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: "'produce_topics' lists the topic 'pubsub.orders' more than once, through references resolving to the same name",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 2},
+						End:      hcl.Pos{Line: 11, Column: 88},
+					},
+				},
+			},
+		},
+		{
+			name: "same topic consumed and produced",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+module "app" {
+	consume_topics = [kafka_topic.orders.name]
+	produce_topics = [kafka_topic.orders.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "same topic consumed and produced when reported",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_app_topics" {
+	enabled                      = true
+	report_produced_and_consumed = true
+}`,
+				"file.tf": `
+resource "kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+	name = "pubsub.invoices"
+}
+
+module "app" {
+	consume_topics = [kafka_topic.orders.name, kafka_topic.invoices.name]
+	produce_topics = [kafka_topic.orders.name]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: "module 'app' both produces and consumes the topic 'pubsub.orders': make sure it is intended and document it",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 2},
+						End:      hcl.Pos{Line: 11, Column: 71},
+					},
+				},
+			},
+		},
+		{
+			name: "different topics consumed and produced when reported",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_app_topics" {
+	enabled                      = true
+	report_produced_and_consumed = true
+}`,
+				"file.tf": `
+resource "kafka_topic" "orders" {
+	name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+	name = "pubsub.invoices"
+}
+
+module "app" {
+	consume_topics = [kafka_topic.orders.name]
+	produce_topics = [kafka_topic.invoices.name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "consuming from a topic not in module in a single line list",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.orders.name, "some_topic", kafka_topic.invoices.name]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_topics' may only contain topics defined in the current module but 'some_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 86},
+					},
+				},
+			},
+			fixed: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.orders.name, kafka_topic.invoices.name]
+}
+`,
+		},
+		{
+			name: "consuming from topics not in module at the end of a single line list",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "consumer" {
+  consume_topics = ["pubsub.orders", "first_topic", "second_topic"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'consume_topics' may only contain topics defined in the current module but 'first_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 68},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'consume_topics' may only contain topics defined in the current module but 'second_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 68},
+					},
+				},
+			},
+			fixed: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "consumer" {
+  consume_topics = ["pubsub.orders"]
+}
+`,
+		},
+		{
+			name: "producing to topics not in module in a multi-line list",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}
+
+module "producer" {
+  produce_topics = [
+    kafka_topic.orders.name,
+    "some_topic",
+    kafka_topic.invoices.name,
+    "other_topic",
+  ]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'produce_topics' may only contain topics defined in the current module but 'some_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 16, Column: 4},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'produce_topics' may only contain topics defined in the current module but 'other_topic' is not",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 16, Column: 4},
+					},
+				},
+			},
+			fixed: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}
+
+module "producer" {
+  produce_topics = [
+    kafka_topic.orders.name,
+    kafka_topic.invoices.name,
+  ]
+}
+`,
+		},
+		{
+			name: "no modules nor topics",
+			files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk/pubsub/tfstate"
+    region = "eu-west-1"
+  }
+}
 `,
 			},
 			expected: []*helper.Issue{},
@@ -192,6 +568,12 @@ resource "some_resource" "some_other_resource" {
 			require.NoError(t, rule.Check(runner))
 
 			helper.AssertIssues(t, tc.expected, runner.Issues)
+			for i, issue := range runner.Issues {
+				assert.Equal(t, tc.expected[i].Rule.Severity(), issue.Rule.Severity())
+			}
+			if tc.fixed != "" {
+				helper.AssertChanges(t, map[string]string{"file.tf": tc.fixed}, runner.Changes())
+			}
 		})
 	}
 }
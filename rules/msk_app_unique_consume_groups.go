@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKAppUniqueConsumeGroupsRule checks that a consume group is used by a single app.
+type MSKAppUniqueConsumeGroupsRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKAppUniqueConsumeGroupsRule) Name() string {
+	return "msk_app_unique_consume_groups"
+}
+
+func (r *MSKAppUniqueConsumeGroupsRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKAppUniqueConsumeGroupsRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKAppUniqueConsumeGroupsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKAppUniqueConsumeGroupsRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	appBlocks, err := getTLSApps(runner)
+	if err != nil {
+		return err
+	}
+	// the files are not returned in a stable order, so the first app using a group is the first one by file name
+	slices.SortStableFunc(appBlocks, func(a, b *hclext.Block) int {
+		return cmp.Or(
+			strings.Compare(a.DefRange.Filename, b.DefRange.Filename),
+			a.DefRange.Start.Byte-b.DefRange.Start.Byte,
+		)
+	})
+
+	// consume group -> name of the first app using it
+	groupApps := map[string]string{}
+	for _, block := range appBlocks {
+		appName := block.Labels[0]
+		consumeGroupAttr := block.Body.Attributes[consumeGroupAttrName]
+
+		var consumeGroupNames []string
+		if err := runner.EvaluateExpr(consumeGroupAttr.Expr, &consumeGroupNames, nil); err != nil {
+			return fmt.Errorf("decoding attribute '%s': %v", consumeGroupAttrName, err)
+		}
+
+		for _, name := range consumeGroupNames {
+			otherApp, used := groupApps[name]
+			if !used {
+				groupApps[name] = appName
+				continue
+			}
+			if otherApp == appName {
+				continue
+			}
+
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf(
+					"consume group '%s' of app '%s' is already used by app '%s': consume groups must be unique to an app",
+					name,
+					appName,
+					otherApp,
+				),
+				consumeGroupAttr.Range,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: consume group used by multiple apps: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
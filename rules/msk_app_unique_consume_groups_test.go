@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKAppUniqueConsumeGroupsRule(t *testing.T) {
+	rule := &MSKAppUniqueConsumeGroupsRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "consume group used by two apps",
+			files: map[string]string{
+				"file.tf": `
+module "first-app" {
+	consume_groups = ["pubsub.my-group"]
+}
+
+module "second-app" {
+	consume_groups = ["pubsub.other-group", "pubsub.my-group"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "consume group 'pubsub.my-group' of app 'second-app' is already used by app 'first-app': consume groups must be unique to an app",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 60},
+					},
+				},
+			},
+		},
+		{
+			name: "consume group used by three apps",
+			files: map[string]string{
+				"file.tf": `
+module "first-app" {
+	consume_groups = ["pubsub.my-group"]
+}
+
+module "second-app" {
+	consume_groups = ["pubsub.my-group"]
+}
+
+module "third-app" {
+	consume_groups = ["pubsub.my-group"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "consume group 'pubsub.my-group' of app 'second-app' is already used by app 'first-app': consume groups must be unique to an app",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 38},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "consume group 'pubsub.my-group' of app 'third-app' is already used by app 'first-app': consume groups must be unique to an app",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 11, Column: 2},
+						End:      hcl.Pos{Line: 11, Column: 38},
+					},
+				},
+			},
+		},
+		{
+			name: "consume group used by apps in different files",
+			files: map[string]string{
+				"indexer.tf": `
+module "indexer" {
+	consume_groups = ["pubsub.my-group"]
+}
+`,
+				"exporter.tf": `
+module "exporter" {
+	consume_groups = ["pubsub.my-group"]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "consume group 'pubsub.my-group' of app 'indexer' is already used by app 'exporter': consume groups must be unique to an app",
+					Range: hcl.Range{
+						Filename: "indexer.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+			},
+		},
+		{
+			name: "unique consume groups",
+			files: map[string]string{
+				"file.tf": `
+module "first-app" {
+	consume_groups = ["pubsub.my-group"]
+}
+
+module "second-app" {
+	consume_groups = ["pubsub.other-group"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -2,7 +2,8 @@ package rules
 
 import (
 	"fmt"
-	"path/filepath"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -12,9 +13,34 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
+type mskModuleBackendRuleConfig struct {
+	AllowedRegions []string            `hclext:"allowed_regions,optional"`
+	KeyFormat      string              `hclext:"key_format,optional"`
+	AuthMethods    map[string][]string `hclext:"auth_methods,optional"`
+}
+
+// defaultBackendAuthMethods are the attributes of the s3 backend selecting the credentials, by auth method.
+var defaultBackendAuthMethods = map[string][]string{
+	"profile":     {"profile"},
+	"role_arn":    {"role_arn"},
+	"static_keys": {"access_key", "secret_key"},
+}
+
+const (
+	// defaultBackendKeyFormat builds keys like 'dev-aws/msk-shared-pubsub'.
+	defaultBackendKeyFormat = "{env}/{cluster}-{team}"
+	// defaultBackendKeyFormatDesc describes the default format, where the env directory is made of the env and the platform.
+	defaultBackendKeyFormatDesc = "${env}-${platform}/${msk-cluster}-${team-name}"
+)
+
 // MSKModuleBackendRule checks whether an MSK module has an S3 backend defined with the following restrictions:
-//   - the key is in the format ${env}-${platform}/${msk-cluster}-${team-name}
+//   - the key is in the format ${env}-${platform}/${msk-cluster}-${team-name}, or the configured key_format
+//   - the key is lowercase
 //   - the bucket contains the environment in its name
+//   - the bucket and the key are static strings
+//   - the region is defined and, when configured, one of the allowed regions
+//   - the state locking is configured with either a dynamodb table or a lockfile
+//   - the credentials are configured with a single auth method
 type MSKModuleBackendRule struct {
 	tflint.DefaultRule
 }
@@ -39,7 +65,23 @@ func (r *MSKModuleBackendRule) Link() string {
 	return ReferenceLink(r.Name())
 }
 
-func (r *MSKModuleBackendRule) getBackendContent(runner tflint.Runner) (*hclext.BodyContent, error) {
+func (r *MSKModuleBackendRule) getBackendContent(
+	runner tflint.Runner,
+	authMethods map[string][]string,
+) (*hclext.BodyContent, error) {
+	attributes := []hclext.AttributeSchema{
+		{Name: "bucket"},
+		{Name: "key"},
+		{Name: "region"},
+		{Name: "dynamodb_table"},
+		{Name: "use_lockfile"},
+	}
+	for _, method := range slices.Sorted(maps.Keys(authMethods)) {
+		for _, attrName := range authMethods[method] {
+			attributes = append(attributes, hclext.AttributeSchema{Name: attrName})
+		}
+	}
+
 	//nolint:wrapcheck
 	return runner.GetModuleContent(&hclext.BodySchema{
 		Blocks: []hclext.BlockSchema{
@@ -51,10 +93,7 @@ func (r *MSKModuleBackendRule) getBackendContent(runner tflint.Runner) (*hclext.
 							Type:       "backend",
 							LabelNames: []string{"type"},
 							Body: &hclext.BodySchema{
-								Attributes: []hclext.AttributeSchema{
-									{Name: "bucket"},
-									{Name: "key"},
-								},
+								Attributes: attributes,
 							},
 						},
 					},
@@ -74,7 +113,15 @@ func (r *MSKModuleBackendRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
-	content, err := r.getBackendContent(runner)
+	var config mskModuleBackendRuleConfig
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if config.AuthMethods == nil {
+		config.AuthMethods = defaultBackendAuthMethods
+	}
+
+	content, err := r.getBackendContent(runner, config.AuthMethods)
 	if err != nil {
 		return fmt.Errorf("getting module content: %w", err)
 	}
@@ -87,6 +134,18 @@ func (r *MSKModuleBackendRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	if err := r.checkBackendRegion(runner, backend, config.AllowedRegions); err != nil {
+		return err
+	}
+
+	if err := r.checkBackendLocking(runner, backend); err != nil {
+		return err
+	}
+
+	if err := r.checkBackendAuth(runner, backend, config.AuthMethods); err != nil {
+		return err
+	}
+
 	modInfo, err := r.parseModuleInfo(runner, backend)
 	if err != nil {
 		return err
@@ -98,7 +157,7 @@ func (r *MSKModuleBackendRule) Check(runner tflint.Runner) error {
 	if err := r.checkBackendBucketFormat(runner, backend, *modInfo); err != nil {
 		return err
 	}
-	return r.checkBackendKeyFormat(runner, backend, *modInfo)
+	return r.checkBackendKeyFormat(runner, backend, *modInfo, config.KeyFormat)
 }
 
 func (r *MSKModuleBackendRule) validateBackendDef(
@@ -161,13 +220,13 @@ func (r *MSKModuleBackendRule) checkBackendBucketFormat(
 		return nil
 	}
 
-	var bucket string
-	diags := gohcl.DecodeExpression(bucketAttr.Expr, nil, &bucket)
-	if diags.HasErrors() {
-		return diags
+	isStatic, err := r.checkStaticAttr(runner, bucketAttr)
+	if err != nil || !isStatic {
+		return err
 	}
 
-	diags = gohcl.DecodeExpression(bucketAttr.Expr, nil, &bucket)
+	var bucket string
+	diags := gohcl.DecodeExpression(bucketAttr.Expr, nil, &bucket)
 	if diags.HasErrors() {
 		return diags
 	}
@@ -190,7 +249,12 @@ func (r *MSKModuleBackendRule) checkBackendBucketFormat(
 	return nil
 }
 
-func (r *MSKModuleBackendRule) checkBackendKeyFormat(runner tflint.Runner, backend *hclext.Block, mi moduleInfo) error {
+func (r *MSKModuleBackendRule) checkBackendKeyFormat(
+	runner tflint.Runner,
+	backend *hclext.Block,
+	mi moduleInfo,
+	keyFormat string,
+) error {
 	keyAttr, keyExists := backend.Body.Attributes["key"]
 	if !keyExists {
 		err := runner.EmitIssue(
@@ -204,19 +268,46 @@ func (r *MSKModuleBackendRule) checkBackendKeyFormat(runner tflint.Runner, backe
 		return nil
 	}
 
+	isStatic, err := r.checkStaticAttr(runner, keyAttr)
+	if err != nil || !isStatic {
+		return err
+	}
+
 	var key string
 	diags := gohcl.DecodeExpression(keyAttr.Expr, nil, &key)
 	if diags.HasErrors() {
 		return diags
 	}
 
-	expectedKey := fmt.Sprintf("%s/%s-%s", mi.env, mi.mskCluster, mi.teamName)
+	lowerKey := strings.ToLower(key)
+	if key != lowerKey {
+		err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("backend key must be lowercase, as s3 keys are case-sensitive. Expected: '%s', current: '%s'", lowerKey, key),
+			keyAttr.Range,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(keyAttr.Expr.Range(), fmt.Sprintf("%q", lowerKey))
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: key not lowercase: %w", err)
+		}
+		return nil
+	}
+
+	keyFormatDesc := keyFormat
+	if keyFormat == "" {
+		keyFormat = defaultBackendKeyFormat
+		keyFormatDesc = defaultBackendKeyFormatDesc
+	}
+	expectedKey := buildBackendKey(keyFormat, mi)
 
 	if key != expectedKey {
 		err := runner.EmitIssue(
 			r,
 			fmt.Sprintf(
-				"backend key must have the following format: ${env}-${platform}/${msk-cluster}-${team-name}. Expected: '%s', current: '%s'",
+				"backend key must have the following format: %s. Expected: '%s', current: '%s'",
+				keyFormatDesc,
 				expectedKey,
 				key,
 			),
@@ -230,14 +321,173 @@ func (r *MSKModuleBackendRule) checkBackendKeyFormat(runner tflint.Runner, backe
 	return nil
 }
 
+func (r *MSKModuleBackendRule) checkBackendRegion(
+	runner tflint.Runner,
+	backend *hclext.Block,
+	allowedRegions []string,
+) error {
+	regionAttr, regionExists := backend.Body.Attributes["region"]
+	if !regionExists {
+		err := runner.EmitIssue(
+			r,
+			"the s3 backend should specify the region inside the kafka MSK module",
+			backend.DefRange,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: no s3 region: %w", err)
+		}
+		return nil
+	}
+
+	isStatic, err := r.checkStaticAttr(runner, regionAttr)
+	if err != nil || !isStatic {
+		return err
+	}
+
+	// no allowed regions configured: only the presence of the region is required
+	if len(allowedRegions) == 0 {
+		return nil
+	}
+
+	var region string
+	diags := gohcl.DecodeExpression(regionAttr.Expr, nil, &region)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if !slices.Contains(allowedRegions, region) {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"backend region must be one of the allowed regions: '%s', current: '%s'",
+				strings.Join(allowedRegions, "', '"),
+				region,
+			),
+			regionAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: region not allowed: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkBackendLocking reports the backends without state locking, which protects the state from concurrent applies.
+func (r *MSKModuleBackendRule) checkBackendLocking(runner tflint.Runner, backend *hclext.Block) error {
+	if _, ok := backend.Body.Attributes["dynamodb_table"]; ok {
+		return nil
+	}
+
+	if lockfileAttr, ok := backend.Body.Attributes["use_lockfile"]; ok {
+		var useLockfile bool
+		diags := gohcl.DecodeExpression(lockfileAttr.Expr, nil, &useLockfile)
+		if diags.HasErrors() {
+			logger.Debug("skipping use_lockfile which is not a static bool", "diags", diags.Error())
+			return nil
+		}
+		if useLockfile {
+			return nil
+		}
+	}
+
+	err := runner.EmitIssue(
+		r,
+		"the s3 backend should configure the state locking with either 'dynamodb_table' or 'use_lockfile = true', to prevent concurrent applies from corrupting the state",
+		backend.DefRange,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: no state locking: %w", err)
+	}
+	return nil
+}
+
+// checkBackendAuth reports the backends setting the attributes of several auth methods, like both a profile and
+// static keys, as the credentials actually used are hard to tell.
+func (r *MSKModuleBackendRule) checkBackendAuth(
+	runner tflint.Runner,
+	backend *hclext.Block,
+	authMethods map[string][]string,
+) error {
+	var usedMethods []string
+	for _, method := range slices.Sorted(maps.Keys(authMethods)) {
+		if slices.ContainsFunc(authMethods[method], func(attrName string) bool {
+			_, ok := backend.Body.Attributes[attrName]
+			return ok
+		}) {
+			usedMethods = append(usedMethods, method)
+		}
+	}
+	if len(usedMethods) < 2 {
+		return nil
+	}
+
+	err := runner.EmitIssue(
+		r,
+		fmt.Sprintf(
+			"the s3 backend should configure the credentials with a single auth method, as the credentials used are ambiguous otherwise: it configures '%s'",
+			strings.Join(usedMethods, "', '"),
+		),
+		backend.DefRange,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: conflicting auth methods: %w", err)
+	}
+	return nil
+}
+
+// buildBackendKey replaces the placeholders of the key format with the module info:
+//   - {env}: the env directory, like 'dev-aws'
+//   - {platform}: the platform part of the env directory, like 'aws'
+//   - {cluster}: the msk cluster directory
+//   - {team}: the team directory
+func buildBackendKey(keyFormat string, mi moduleInfo) string {
+	_, platform, _ := strings.Cut(mi.env, "-")
+	return strings.NewReplacer(
+		"{env}", mi.env,
+		"{platform}", platform,
+		"{cluster}", mi.mskCluster,
+		"{team}", mi.teamName,
+	).Replace(keyFormat)
+}
+
+// checkStaticAttr reports the backend attributes using variables or interpolation, which can't be validated.
+func (r *MSKModuleBackendRule) checkStaticAttr(runner tflint.Runner, attr *hclext.Attribute) (bool, error) {
+	if _, diags := attr.Expr.Value(nil); !diags.HasErrors() {
+		return true, nil
+	}
+
+	err := runner.EmitIssue(
+		r,
+		fmt.Sprintf(
+			"the s3 backend %s must be a static string, as backends don't support variables or interpolation",
+			attr.Name,
+		),
+		attr.Range,
+	)
+	if err != nil {
+		return false, fmt.Errorf("emitting issue: backend %s not static: %w", attr.Name, err)
+	}
+	return false, nil
+}
+
 func (r *MSKModuleBackendRule) parseModuleInfo(runner tflint.Runner, backend *hclext.Block) (*moduleInfo, error) {
 	modulePath, err := runner.GetOriginalwd()
 	if err != nil {
-		return nil, fmt.Errorf("failed getting module path: %w", err)
+		logger.Debug("failed getting module path", "err", err)
+		// noticed once here, while the other rules deriving checks from the module path skip them silently
+		err = runner.EmitIssue(
+			withSeverity(r, tflint.NOTICE),
+			fmt.Sprintf("skipping the checks derived from the module path, as it can't be determined: %s", err),
+			backend.DefRange,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("emitting issue: module path unavailable: %w", err)
+		}
+		return nil, nil
 	}
 
-	pathElems := strings.Split(filepath.Clean(modulePath), string(filepath.Separator))
-	if len(pathElems) < 3 {
+	pathElems := splitModulePath(modulePath)
+	if len(pathElems) < minModulePathElems {
 		err := runner.EmitIssue(
 			r,
 			fmt.Sprintf(
@@ -1,12 +1,14 @@
 package rules
 
 import (
+	"errors"
 	"path/filepath"
 	"testing"
 
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_MSKModuleBackend(t *testing.T) {
@@ -19,6 +21,7 @@ func Test_MSKModuleBackend(t *testing.T) {
 		Files    map[string]string
 		WorkDir  string
 		Expected helper.Issues
+		Fixed    string
 	}{
 		{
 			Name:    "no terraform config defined",
@@ -74,6 +77,9 @@ terraform {
 terraform {
   backend "s3" {
     key = "dev-aws/kafka-shared-msk-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -95,6 +101,9 @@ terraform {
 terraform {
   backend "s3" {
     bucket = "dummy-dev--bucket"
+    region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -118,6 +127,8 @@ terraform {
     bucket = "my-dev-bucket"
     key    = "prod-aws/msk-cluster-pubsub"
     region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -141,6 +152,8 @@ terraform {
     bucket = "my-dev-bucket"
     key    = "dev-merit/dummy-cluster-otel"
     region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -164,6 +177,8 @@ terraform {
     bucket = "my-dev-bucket"
     key    = "dev-aws/msk-cluster-dummy-key"
     region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -187,6 +202,8 @@ terraform {
     bucket = "my-bucket"
     key    = "prod-aws/msk-cluster-pubsub"
     region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -201,6 +218,41 @@ terraform {
 				},
 			},
 		},
+		{
+			Name:    "backend key contains uppercase letters",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/Kafka-Shared-MSK-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "backend key must be lowercase, as s3 keys are case-sensitive. Expected: 'dev-aws/kafka-shared-msk-pubsub', current: 'dev-aws/Kafka-Shared-MSK-pubsub'",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 47},
+					},
+				},
+			},
+			Fixed: `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`,
+		},
 		{
 			Name:    "module is not in the expected structure",
 			WorkDir: filepath.Join("config", "kafka-cluster-config"),
@@ -210,6 +262,8 @@ terraform {
     bucket = "my-bucket"
     key    = "prod-aws/msk-cluster-pubsub"
     region = "us-east-1"
+
+    use_lockfile = true
   }
 }`},
 			Expected: helper.Issues{
@@ -224,6 +278,371 @@ terraform {
 				},
 			},
 		},
+		{
+			Name:    "module path with exactly the expected elements",
+			WorkDir: filepath.Join("dev-aws", "msk-cluster", "pubsub"),
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/msk-cluster-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "absolute module path with one element less than expected",
+			WorkDir: string(filepath.Separator) + filepath.Join("msk-cluster", "pubsub"),
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/msk-cluster-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the module doesn't have the expected structure: the path should end with '${env}-${platform}/${msk-cluster}/${team-name}', but it is: /msk-cluster/pubsub",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name:    "interpolated bucket",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "${var.bucket}"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend bucket must be a static string, as backends don't support variables or interpolation",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 4, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name:    "interpolated key",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-${var.team}"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend key must be a static string, as backends don't support variables or interpolation",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 52},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend doesn't specify the region",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+
+    use_lockfile = true
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend should specify the region inside the kafka MSK module",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend region not allowed",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "msk_module_backend" {
+  enabled         = true
+  allowed_regions = ["eu-west-1", "eu-west-2"]
+}`,
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "us-east-1"
+
+    use_lockfile = true
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "backend region must be one of the allowed regions: 'eu-west-1', 'eu-west-2', current: 'us-east-1'",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend region allowed",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "msk_module_backend" {
+  enabled         = true
+  allowed_regions = ["eu-west-1", "eu-west-2"]
+}`,
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "eu-west-2"
+
+    use_lockfile = true
+  }
+}`,
+			},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "backend key doesn't follow the configured format",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "msk_module_backend" {
+  enabled    = true
+  key_format = "{platform}/{cluster}/{team}.tfstate"
+}`,
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = true
+  }
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "backend key must have the following format: {platform}/{cluster}/{team}.tfstate. Expected: 'aws/kafka-shared-msk/pubsub.tfstate', current: 'dev-aws/kafka-shared-msk-pubsub'",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend key follows the configured format",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "msk_module_backend" {
+  enabled    = true
+  key_format = "{platform}/{cluster}/{team}.tfstate"
+}`,
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "aws/kafka-shared-msk/pubsub.tfstate"
+    region       = "us-east-1"
+    use_lockfile = true
+  }
+}`,
+			},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "backend doesn't configure the state locking",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "us-east-1"
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend should configure the state locking with either 'dynamodb_table' or 'use_lockfile = true', to prevent concurrent applies from corrupting the state",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend disables the lockfile",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = false
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend should configure the state locking with either 'dynamodb_table' or 'use_lockfile = true', to prevent concurrent applies from corrupting the state",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend locks the state with a dynamodb table",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket         = "my-dev-bucket"
+    key            = "dev-aws/kafka-shared-msk-pubsub"
+    region         = "us-east-1"
+    dynamodb_table = "terraform-state-lock"
+  }
+}`},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "backend locks the state with a lockfile",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = true
+  }
+}`},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "backend configures conflicting auth methods",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = true
+    profile      = "kafka-dev"
+    role_arn     = "arn:aws:iam::123456789012:role/terraform"
+    access_key   = "AKIAEXAMPLE"
+    secret_key   = "secret"
+  }
+}`},
+			Expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the s3 backend should configure the credentials with a single auth method, as the credentials used are ambiguous otherwise: it configures 'profile', 'role_arn', 'static_keys'",
+					Range: hcl.Range{
+						Filename: "backend.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name:    "backend configures a single auth method",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = true
+    access_key   = "AKIAEXAMPLE"
+    secret_key   = "secret"
+  }
+}`},
+			Expected: []*helper.Issue{},
+		},
+		{
+			Name:    "backend configures auth methods allowed together by the config",
+			WorkDir: defaultWorkDir,
+			Files: map[string]string{
+				".tflint.hcl": `
+rule "msk_module_backend" {
+  enabled      = true
+  auth_methods = {
+    assumed_role = ["profile", "role_arn"]
+    static_keys  = ["access_key", "secret_key"]
+  }
+}`,
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket       = "my-dev-bucket"
+    key          = "dev-aws/kafka-shared-msk-pubsub"
+    region       = "us-east-1"
+    use_lockfile = true
+    profile      = "kafka-dev"
+    role_arn     = "arn:aws:iam::123456789012:role/terraform"
+  }
+}`,
+			},
+			Expected: []*helper.Issue{},
+		},
 		{
 			Name:    "good backend defined in second terraform config",
 			WorkDir: defaultWorkDir,
@@ -238,6 +657,8 @@ terraform {
 	bucket = "my-dev-bucket"
 	key    = "dev-aws/kafka-shared-msk-pubsub"
 	region = "us-east-1"
+
+    use_lockfile = true
   }
 }`,
 			},
@@ -254,6 +675,9 @@ terraform {
 			}
 
 			helper.AssertIssues(t, test.Expected, runner.Issues)
+			if test.Fixed != "" {
+				require.Equal(t, test.Fixed, string(runner.Changes()["backend.tf"]))
+			}
 		})
 	}
 }
@@ -272,3 +696,61 @@ func WithWorkDir(h *helper.Runner, workDir string) *RunnerWithWorkDir {
 func (r *RunnerWithWorkDir) GetOriginalwd() (string, error) {
 	return r.workDir, nil
 }
+
+type RunnerWithoutWorkDir struct {
+	*helper.Runner
+}
+
+// WithoutWorkDir constructs a runner failing when calling Originalwd, like older tflint versions not supporting it.
+func WithoutWorkDir(h *helper.Runner) *RunnerWithoutWorkDir {
+	return &RunnerWithoutWorkDir{Runner: h}
+}
+
+// Returns an error, as the workdir is unavailable.
+func (r *RunnerWithoutWorkDir) GetOriginalwd() (string, error) {
+	return "", errors.New("GetOriginalwd is not supported")
+}
+
+func Test_MSKModuleBackendWithoutWorkDir(t *testing.T) {
+	rule := &MSKModuleBackendRule{}
+
+	runner := WithoutWorkDir(helper.TestRunner(t, map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "my-dev-bucket"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+  }
+}`}))
+
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "the s3 backend should specify the region inside the kafka MSK module",
+			Range: hcl.Range{
+				Filename: "backend.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 15},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "the s3 backend should configure the state locking with either 'dynamodb_table' or 'use_lockfile = true', to prevent concurrent applies from corrupting the state",
+			Range: hcl.Range{
+				Filename: "backend.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 15},
+			},
+		},
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "skipping the checks derived from the module path, as it can't be determined: GetOriginalwd is not supported",
+			Range: hcl.Range{
+				Filename: "backend.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 15},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// providerRegionAttributes are the attributes of the providers selecting the AWS region, by provider name.
+var providerRegionAttributes = map[string]string{
+	"aws":   "region",
+	"kafka": "sasl_aws_region",
+}
+
+// MSKModuleProviderRegionRule checks that the region of the aws and kafka providers matches the s3 backend region,
+// as an MSK module is deployed in a single region.
+// It is disabled by default, as the state bucket can be in another region than the resources of the module.
+type MSKModuleProviderRegionRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKModuleProviderRegionRule) Name() string {
+	return "msk_module_provider_region"
+}
+
+func (r *MSKModuleProviderRegionRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKModuleProviderRegionRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKModuleProviderRegionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKModuleProviderRegionRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	backendRegion, ok, err := getBackendRegion(runner)
+	if err != nil || !ok {
+		return err
+	}
+
+	providers, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "provider",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{
+							{Name: "alias"},
+							{Name: providerRegionAttributes["aws"]},
+							{Name: providerRegionAttributes["kafka"]},
+						},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting providers: %w", err)
+	}
+
+	for _, provider := range providers.Blocks {
+		if err := r.validateProviderRegion(runner, provider, backendRegion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKModuleProviderRegionRule) validateProviderRegion(
+	runner tflint.Runner,
+	provider *hclext.Block,
+	backendRegion string,
+) error {
+	providerName := provider.Labels[0]
+	regionAttrName, ok := providerRegionAttributes[providerName]
+	if !ok {
+		return nil
+	}
+	// the aliased providers are meant for resources in other regions, like the replicas of a bucket
+	if _, aliased := provider.Body.Attributes["alias"]; aliased {
+		return nil
+	}
+
+	regionAttr, ok := provider.Body.Attributes[regionAttrName]
+	if !ok {
+		return nil
+	}
+
+	var region string
+	diags := gohcl.DecodeExpression(regionAttr.Expr, nil, &region)
+	if diags.HasErrors() {
+		logger.Debug("skipping provider region which is not a static string", "provider", providerName)
+		return nil
+	}
+	if region == backendRegion {
+		return nil
+	}
+
+	err := runner.EmitIssue(
+		r,
+		fmt.Sprintf(
+			"the %s provider %s '%s' must match the s3 backend region '%s', as the module is deployed in a single region",
+			providerName,
+			regionAttrName,
+			region,
+			backendRegion,
+		),
+		regionAttr.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: provider region mismatch: %w", err)
+	}
+	return nil
+}
+
+// getBackendRegion returns the region of the s3 backend, or false when it has none which can be statically known.
+// The missing backend or region is reported by the msk_module_backend rule.
+func getBackendRegion(runner tflint.Runner) (string, bool, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type:       "backend",
+							LabelNames: []string{"type"},
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "region"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return "", false, fmt.Errorf("getting module content: %w", err)
+	}
+
+	backend := findBackendDef(content)
+	if backend == nil || backend.Labels[0] != "s3" {
+		return "", false, nil
+	}
+	regionAttr, ok := backend.Body.Attributes["region"]
+	if !ok {
+		return "", false, nil
+	}
+
+	var region string
+	diags := gohcl.DecodeExpression(regionAttr.Expr, nil, &region)
+	if diags.HasErrors() {
+		logger.Debug("skipping backend region which is not a static string", "diags", diags.Error())
+		return "", false, nil
+	}
+	return region, true, nil
+}
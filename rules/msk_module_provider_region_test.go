@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+const providerRegionTestBackend = `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+    region = "eu-west-1"
+  }
+}
+`
+
+func Test_MSKModuleProviderRegionRule(t *testing.T) {
+	rule := &MSKModuleProviderRegionRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "providers in the backend region",
+			files: map[string]string{
+				"backend.tf": providerRegionTestBackend,
+				"providers.tf": `
+provider "aws" {
+  region = "eu-west-1"
+}
+
+provider "kafka" {
+  bootstrap_servers = ["broker:9098"]
+  sasl_mechanism    = "aws-iam"
+  sasl_aws_region   = "eu-west-1"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "providers in another region than the backend",
+			files: map[string]string{
+				"backend.tf": providerRegionTestBackend,
+				"providers.tf": `
+provider "aws" {
+  region = "us-east-1"
+}
+
+provider "kafka" {
+  bootstrap_servers = ["broker:9098"]
+  sasl_mechanism    = "aws-iam"
+  sasl_aws_region   = "eu-west-2"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "the aws provider region 'us-east-1' must match the s3 backend region 'eu-west-1', as the module is deployed in a single region",
+					Range: hcl.Range{
+						Filename: "providers.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "the kafka provider sasl_aws_region 'eu-west-2' must match the s3 backend region 'eu-west-1', as the module is deployed in a single region",
+					Range: hcl.Range{
+						Filename: "providers.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			name: "aliased provider in another region",
+			files: map[string]string{
+				"backend.tf": providerRegionTestBackend,
+				"providers.tf": `
+provider "aws" {
+  region = "eu-west-1"
+}
+
+provider "aws" {
+  alias  = "replica"
+  region = "eu-central-1"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "provider region from a variable",
+			files: map[string]string{
+				"backend.tf": providerRegionTestBackend,
+				"providers.tf": `
+provider "aws" {
+  region = var.region
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "backend without region",
+			files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk-pubsub"
+  }
+}
+`,
+				"providers.tf": `
+provider "aws" {
+  region = "us-east-1"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
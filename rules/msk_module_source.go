@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKModuleSourceRule checks that the local sources of the modules, like the tls-app one, resolve to existing directories.
+type MSKModuleSourceRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKModuleSourceRule) Name() string {
+	return "msk_module_source"
+}
+
+func (r *MSKModuleSourceRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKModuleSourceRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKModuleSourceRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKModuleSourceRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{{Name: "source"}},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+	if len(modules.Blocks) == 0 {
+		return nil
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+
+	for _, block := range modules.Blocks {
+		sourceAttr, ok := block.Body.Attributes["source"]
+		if !ok {
+			continue
+		}
+
+		var source string
+		diags := gohcl.DecodeExpression(sourceAttr.Expr, nil, &source)
+		if diags.HasErrors() {
+			logger.Debug("skipping module with a source which is not a static string", "labels", block.Labels)
+			continue
+		}
+		// only the local paths can be resolved, the registry and remote sources are downloaded on init
+		if !isLocalModuleSource(source) {
+			continue
+		}
+
+		sourceDir := filepath.Join(modulePath, filepath.FromSlash(source))
+		if info, err := os.Stat(sourceDir); err == nil && info.IsDir() {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"module '%s' has the source '%s' which is not an existing directory: 'terraform init' will fail",
+				block.Labels[0],
+				source,
+			),
+			sourceAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: module source missing: %w", err)
+		}
+	}
+	return nil
+}
+
+// isLocalModuleSource returns whether the source is a local path, which terraform requires to start with './' or '../'.
+func isLocalModuleSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKModuleSourceRule(t *testing.T) {
+	rule := &MSKModuleSourceRule{}
+
+	rootDir := t.TempDir()
+	workDir := filepath.Join(rootDir, "dev-aws", "kafka-shared-msk", "pubsub")
+	require.NoError(t, os.MkdirAll(workDir, 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "modules", "tls-app"), 0o755))
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "existing source path",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "missing source path",
+			files: map[string]string{
+				"file.tf": `
+module "my_app" {
+  source           = "../../modules/tls-app"
+  cert_common_name = "pubsub/my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "module 'my_app' has the source '../../modules/tls-app' which is not an existing directory: 'terraform init' will fail",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			name: "registry source",
+			files: map[string]string{
+				"file.tf": `
+module "vpc" {
+  source = "terraform-aws-modules/vpc/aws"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	// pluginName is the name of this ruleset, as declared in the plugin blocks of the tflint config.
+	pluginName              = "uw-kafka-config"
+	tflintConfigFileDefault = ".tflint.hcl"
+)
+
+type mskPluginVersionRuleConfig struct {
+	ConfigFile string `hclext:"config_file,optional"`
+}
+
+// MSKPluginVersionRule checks that the tflint config pins the version of this ruleset,
+// so the modules are linted consistently by all the teams and the pipelines.
+type MSKPluginVersionRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKPluginVersionRule) Name() string {
+	return "msk_plugin_version"
+}
+
+func (r *MSKPluginVersionRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKPluginVersionRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKPluginVersionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+var tflintConfigPluginsSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "plugin", LabelNames: []string{"name"}}},
+}
+
+func (r *MSKPluginVersionRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskPluginVersionRuleConfig{ConfigFile: tflintConfigFileDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	file, ok, err := readTFLintConfigFile(runner, ruleConfig.ConfigFile)
+	if err != nil || !ok {
+		return err
+	}
+
+	content, _, diags := file.Body.PartialContent(tflintConfigPluginsSchema)
+	if diags.HasErrors() {
+		return fmt.Errorf("reading plugins of tflint config file %s: %w", ruleConfig.ConfigFile, diags)
+	}
+
+	for _, plugin := range content.Blocks {
+		if plugin.Labels[0] != pluginName {
+			continue
+		}
+		return r.validatePluginVersion(runner, plugin)
+	}
+	return nil
+}
+
+// readTFLintConfigFile parses the tflint config file, relative to the directory tflint runs in.
+// The runner only serves the module files, so the config file is read from the disk.
+// It returns false when the file or the directory is not available.
+func readTFLintConfigFile(runner tflint.Runner, configFile string) (*hcl.File, bool, error) {
+	path := configFile
+	if !filepath.IsAbs(path) {
+		workDir, err := runner.GetOriginalwd()
+		if err != nil {
+			logger.Debug("skipping tflint config file as the working directory is not available", "err", err)
+			return nil, false, nil
+		}
+		path = filepath.Join(workDir, path)
+	}
+
+	src, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.Debug("skipping tflint config file which is not available", "file", path)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading tflint config file %s: %w", path, err)
+	}
+
+	file, diags := hclparse.NewParser().ParseHCL(src, configFile)
+	if diags.HasErrors() {
+		return nil, false, fmt.Errorf("parsing tflint config file %s: %w", path, diags)
+	}
+	return file, true, nil
+}
+
+func (r *MSKPluginVersionRule) validatePluginVersion(runner tflint.Runner, plugin *hcl.Block) error {
+	attrs, diags := plugin.Body.JustAttributes()
+	if diags.HasErrors() {
+		return fmt.Errorf("reading attributes of plugin '%s': %w", pluginName, diags)
+	}
+
+	versionAttr, hasVersion := attrs["version"]
+	if hasVersion {
+		var version string
+		if diags := gohcl.DecodeExpression(versionAttr.Expr, nil, &version); diags.HasErrors() {
+			return fmt.Errorf("decoding version of plugin '%s': %w", pluginName, diags)
+		}
+		if version != "" {
+			return nil
+		}
+	}
+
+	err := runner.EmitIssue(
+		r,
+		fmt.Sprintf(
+			"the '%s' plugin must be pinned to a version, like 'version = \"x.y.z\"', so the modules are linted consistently",
+			pluginName,
+		),
+		plugin.DefRange,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: plugin version not pinned: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKPluginVersionRule(t *testing.T) {
+	rule := &MSKPluginVersionRule{}
+
+	for _, tc := range []struct {
+		name         string
+		config       string
+		configFile   string
+		tflintConfig string
+		expected     helper.Issues
+	}{
+		{
+			name: "pinned plugin",
+			tflintConfig: `
+plugin "uw-kafka-config" {
+  enabled = true
+
+  version = "1.2.3"
+  source  = "github.com/utilitywarehouse/tflint-ruleset-kafka-config"
+}`,
+			expected: helper.Issues{},
+		},
+		{
+			name: "unpinned plugin",
+			tflintConfig: `
+plugin "aws" {
+  enabled = true
+}
+
+plugin "uw-kafka-config" {
+  enabled = true
+}`,
+			expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the 'uw-kafka-config' plugin must be pinned to a version, like 'version = \"x.y.z\"', so the modules are linted consistently",
+					Range: hcl.Range{
+						Filename: ".tflint.hcl",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "plugin with empty version",
+			tflintConfig: `
+plugin "uw-kafka-config" {
+  enabled = true
+  version = ""
+}`,
+			expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the 'uw-kafka-config' plugin must be pinned to a version, like 'version = \"x.y.z\"', so the modules are linted consistently",
+					Range: hcl.Range{
+						Filename: ".tflint.hcl",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "unpinned plugin in a configured file",
+			config: `
+rule "msk_plugin_version" {
+  enabled     = true
+  config_file = "../.tflint-msk.hcl"
+}`,
+			configFile: "../.tflint-msk.hcl",
+			tflintConfig: `
+plugin "uw-kafka-config" {
+  enabled = true
+}`,
+			expected: helper.Issues{
+				{
+					Rule:    rule,
+					Message: "the 'uw-kafka-config' plugin must be pinned to a version, like 'version = \"x.y.z\"', so the modules are linted consistently",
+					Range: hcl.Range{
+						Filename: "../.tflint-msk.hcl",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name:     "tflint config file not available",
+			expected: helper.Issues{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			files := map[string]string{"main.tf": ""}
+			if tc.config != "" {
+				files[".tflint.hcl"] = tc.config
+			}
+			configFile := tc.configFile
+			if configFile == "" {
+				configFile = ".tflint.hcl"
+			}
+
+			// the tflint config is read from the disk, relative to the directory tflint runs in
+			workDir := filepath.Join(t.TempDir(), "team")
+			require.NoError(t, os.Mkdir(workDir, 0o755))
+			if tc.tflintConfig != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(workDir, configFile), []byte(tc.tflintConfig), 0o600))
+			}
+
+			runner := WithWorkDir(helper.TestRunner(t, files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func Test_MSKPluginVersionRuleWithoutWorkDir(t *testing.T) {
+	rule := &MSKPluginVersionRule{}
+
+	runner := WithoutWorkDir(helper.TestRunner(t, map[string]string{"main.tf": ""}))
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+}
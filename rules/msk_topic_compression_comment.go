@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// compressionCodecRegexp matches the compression codecs mentioned in a comment, like 'clients use snappy'.
+var compressionCodecRegexp = regexp.MustCompile(`(?i)\b(gzip|snappy|lz4|zstd)\b`)
+
+// MSKTopicCompressionCommentRule checks that the comment of the compression type doesn't mention other codecs
+// than the configured one, like a comment left from a previous value.
+type MSKTopicCompressionCommentRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicCompressionCommentRule) Name() string {
+	return "msk_topic_compression_comment"
+}
+
+func (r *MSKTopicCompressionCommentRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicCompressionCommentRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicCompressionCommentRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicCompressionCommentRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		ctPair, hasCt := configKeyToPairMap[compressionTypeKey]
+		if !hasCt {
+			continue
+		}
+		if err := r.validateCompressionComment(runner, ctPair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicCompressionCommentRule) validateCompressionComment(runner tflint.Runner, ctPair hcl.KeyValuePair) error {
+	var ctVal string
+	diags := gohcl.DecodeExpression(ctPair.Value, nil, &ctVal)
+	if diags.HasErrors() {
+		logger.Debug("skipping compression type which is not a static string", "diags", diags.Error())
+		return nil
+	}
+	// with 'producer' the brokers keep the codec of the producers, which any comment can document
+	if ctVal == "producer" {
+		return nil
+	}
+
+	comment, err := getExistingComment(runner, ctPair)
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		return nil
+	}
+
+	var mentioned []string
+	for _, codec := range compressionCodecRegexp.FindAllString(string(comment.Bytes), -1) {
+		codec = strings.ToLower(codec)
+		if !slices.Contains(mentioned, codec) {
+			mentioned = append(mentioned, codec)
+		}
+	}
+	if len(mentioned) == 0 || slices.Contains(mentioned, strings.ToLower(ctVal)) {
+		return nil
+	}
+
+	err = runner.EmitIssue(
+		r,
+		fmt.Sprintf(
+			"the comment of %s mentions '%s', contradicting its value '%s': update the comment or the value",
+			compressionTypeKey,
+			strings.Join(mentioned, "', '"),
+			ctVal,
+		),
+		comment.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: contradicting compression comment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicCompressionCommentRule(t *testing.T) {
+	rule := &MSKTopicCompressionCommentRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "inline comment contradicting the value",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "compression.type" = "zstd" # clients use snappy
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "the comment of compression.type mentions 'snappy', contradicting its value 'zstd': update the comment or the value",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 33},
+						End:      hcl.Pos{Line: 6, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "previous line comment contradicting the value",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    # the producers compress with LZ4 or GZIP
+    "compression.type" = "zstd"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "the comment of compression.type mentions 'lz4', 'gzip', contradicting its value 'zstd': update the comment or the value",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "comment matching the value",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "compression.type" = "zstd" # clients use zstd, like the snappy ones after the upgrade
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "comment without codecs",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    # the best compression ratio
+    "compression.type" = "zstd"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "compression of the producers",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "compression.type" = "producer" # clients use snappy
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -1,7 +1,9 @@
 package rules
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"slices"
 	"strconv"
 	"strings"
@@ -14,6 +16,20 @@ import (
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
+type mskTopicConfigRuleConfig struct {
+	ReplicationFactor          int      `hclext:"replication_factor,optional"`
+	DefaultLocalRetentionDays  int      `hclext:"default_local_retention_days,optional"`
+	AllowedCompressionTypes    []string `hclext:"allowed_compression_types,optional"`
+	DefaultCompressionType     string   `hclext:"default_compression_type,optional"`
+	MaxRetentionMs             int      `hclext:"max_retention_ms,optional"`
+	TieredStorageThresholdDays int      `hclext:"tiered_storage_threshold_days,optional"`
+
+	// the words of the time units configured in the comments rule, for the comments inserted by the fixes
+	timeUnitWords map[string]string
+	// the base comments configured in the comments rule, for the comments inserted and removed by the fixes
+	baseComments map[string]string
+}
+
 // MSKTopicConfigRule checks the configuration for an MSK topic.
 type MSKTopicConfigRule struct {
 	tflint.DefaultRule
@@ -45,8 +61,42 @@ func (r *MSKTopicConfigRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	ruleConfig := mskTopicConfigRuleConfig{
+		ReplicationFactor:          replicationFactorDefault,
+		DefaultLocalRetentionDays:  localRetentionTimeInDaysDefault,
+		AllowedCompressionTypes:    []string{compressionTypeVal},
+		TieredStorageThresholdDays: tieredStorageThresholdInDaysDefault,
+	}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if ruleConfig.ReplicationFactor < 1 {
+		return fmt.Errorf("replication_factor must be a positive integer, got %d", ruleConfig.ReplicationFactor)
+	}
+	if ruleConfig.TieredStorageThresholdDays < 1 {
+		return fmt.Errorf(
+			"tiered_storage_threshold_days must be a positive integer, got %d",
+			ruleConfig.TieredStorageThresholdDays,
+		)
+	}
+	if err := resolveDefaultCompressionType(&ruleConfig); err != nil {
+		return err
+	}
+	if ruleConfig.timeUnitWords, err = getTimeUnitWords(runner); err != nil {
+		return err
+	}
+	if ruleConfig.baseComments, err = getBaseComments(runner); err != nil {
+		return err
+	}
+	logger.Debug("decoded rule config: %v", ruleConfig)
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
 	resourceContents, err := runner.GetResourceContent(
-		"kafka_topic",
+		resourceType,
 		&hclext.BodySchema{
 			Attributes: []hclext.AttributeSchema{
 				{Name: "name"},
@@ -61,7 +111,11 @@ func (r *MSKTopicConfigRule) Check(runner tflint.Runner) error {
 	}
 
 	for _, topicResource := range resourceContents.Blocks {
-		if err := r.validateTopicConfig(runner, topicResource); err != nil {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+		if err := r.validateTopicConfig(runner, topicResource, ruleConfig); err != nil {
 			return err
 		}
 	}
@@ -69,8 +123,18 @@ func (r *MSKTopicConfigRule) Check(runner tflint.Runner) error {
 	return nil
 }
 
-func (r *MSKTopicConfigRule) validateTopicConfig(runner tflint.Runner, topic *hclext.Block) error {
-	if err := r.validateReplicationFactor(runner, topic); err != nil {
+/*
+validateTopicConfig runs all the checks of a topic, several of which can fix the same config.
+The fixes are expressed with the ranges of the original source: the tflint fixer shifts them by the
+previous rewrites of the file and fails on overlapping rewrites, so each check must only rewrite its
+own keys, values and comments.
+*/
+func (r *MSKTopicConfigRule) validateTopicConfig(
+	runner tflint.Runner,
+	topic *hclext.Block,
+	ruleConfig mskTopicConfigRuleConfig,
+) error {
+	if err := r.validateReplicationFactor(runner, topic, ruleConfig.ReplicationFactor); err != nil {
 		return err
 	}
 
@@ -83,17 +147,52 @@ func (r *MSKTopicConfigRule) validateTopicConfig(runner tflint.Runner, topic *hc
 		return nil
 	}
 
+	if !isObjectConfig(configAttr) {
+		if err := r.validateMergedConfigKeysDefinedOnce(runner, configAttr); err != nil {
+			return err
+		}
+		return r.noticeNonObjectConfig(runner, configAttr)
+	}
+
 	/* construct a mapping between the config key and the config KeyPair. This helps in both checking if a key is defined and to propose fixes to the values*/
 	configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
 	if err != nil {
 		return err
 	}
 
-	if err := r.validateCompressionType(runner, configAttr, configKeyToPairMap); err != nil {
+	if err := r.validateConfigKeysDefinedOnce(runner, configAttr, configKeyToPairMap); err != nil {
 		return err
 	}
 
-	if err = r.validateCleanupPolicyConfig(runner, configAttr, configKeyToPairMap); err != nil {
+	if err := r.validateConfigKeysTrimmed(runner, configAttr); err != nil {
+		return err
+	}
+
+	if err := r.noticeReferencedConfigValues(runner, configAttr); err != nil {
+		return err
+	}
+
+	if err := r.validateCompressionType(runner, configAttr, configKeyToPairMap, ruleConfig); err != nil {
+		return err
+	}
+
+	// the durability standard only requires the min insync replicas on the topics with the delete policy
+	isDeletePolicy, err := hasDeletePolicy(configKeyToPairMap)
+	if err != nil {
+		return err
+	}
+	if isDeletePolicy {
+		if err := r.validateMinInSyncReplicas(
+			runner,
+			configAttr,
+			configKeyToPairMap,
+			ruleConfig.ReplicationFactor,
+		); err != nil {
+			return err
+		}
+	}
+
+	if err = r.validateCleanupPolicyConfig(runner, configAttr, configKeyToPairMap, ruleConfig); err != nil {
 		return err
 	}
 	return nil
@@ -103,6 +202,7 @@ func (r *MSKTopicConfigRule) validateCleanupPolicyConfig(
 	runner tflint.Runner,
 	configAttr *hclext.Attribute,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	ruleConfig mskTopicConfigRuleConfig,
 ) error {
 	cleanupPolicy, err := r.getAndValidateCleanupPolicyValue(runner, configAttr, configKeyToPairMap)
 	if err != nil {
@@ -111,7 +211,10 @@ func (r *MSKTopicConfigRule) validateCleanupPolicyConfig(
 
 	switch cleanupPolicy {
 	case cleanupPolicyDelete:
-		if err := r.validateRetentionForDeletePolicy(runner, configAttr, configKeyToPairMap); err != nil {
+		if err := r.validateDeleteRetentionNotDefined(runner, configKeyToPairMap, ruleConfig.baseComments); err != nil {
+			return err
+		}
+		if err := r.validateRetentionForDeletePolicy(runner, configAttr, configKeyToPairMap, ruleConfig); err != nil {
 			return err
 		}
 	case cleanupPolicyCompact:
@@ -119,24 +222,131 @@ func (r *MSKTopicConfigRule) validateCleanupPolicyConfig(
 		if err := r.validateTieredStorageDisabled(runner, configKeyToPairMap, reason); err != nil {
 			return err
 		}
-		if err := r.validateLocalRetentionNotDefined(runner, configKeyToPairMap, reason); err != nil {
+		if err := r.validateLocalRetentionNotDefined(runner, configKeyToPairMap, ruleConfig.baseComments, reason); err != nil {
+			return err
+		}
+		if err := r.validateRetentionTimeNotDefined(runner, configKeyToPairMap, ruleConfig.baseComments, reason); err != nil {
+			return err
+		}
+		if err := r.validateCompactionLags(runner, configKeyToPairMap); err != nil {
+			return err
+		}
+	case cleanupPolicyCompactDelete:
+		// the retention time applies to the deleted segments, while tiered storage is still not supported
+		_, err := r.getAndValidateRetentionTime(runner, configAttr, configKeyToPairMap, ruleConfig.MaxRetentionMs)
+		if err != nil {
+			return err
+		}
+		reason := "compacted topic"
+		if err := r.validateTieredStorageDisabled(runner, configKeyToPairMap, reason); err != nil {
+			return err
+		}
+		if err := r.validateLocalRetentionNotDefined(runner, configKeyToPairMap, ruleConfig.baseComments, reason); err != nil {
 			return err
 		}
-		if err := r.validateRetentionTimeNotDefined(runner, configKeyToPairMap, reason); err != nil {
+		if err := r.validateCompactionLags(runner, configKeyToPairMap); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+/*
+insertConfigEntry inserts the entry at the start of the config map.
+The fixes of several checks can insert entries in the same config. When the config is defined
+on a single line, like 'config = {}', the braces are moved to their own lines by all of them,
+which is done only once, so the inserted entries are separated from the existing ones.
+*/
+func insertConfigEntry(f tflint.Fixer, config *hclext.Attribute, entry string) error {
+	objExpr, ok := config.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok || objExpr.OpenRange.Start.Line != objExpr.SrcRange.End.Line {
+		return f.InsertTextAfter(config.Expr.StartRange(), "\n"+entry) //nolint:wrapcheck
+	}
+
+	// replacing the same range again with the same text doesn't change anything
+	if err := f.ReplaceText(objExpr.OpenRange, "{\n"); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if err := f.InsertTextAfter(objExpr.OpenRange, entry+"\n"); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if len(objExpr.Items) == 0 {
+		return nil
+	}
+
+	closeRange := hcl.Range{
+		Filename: objExpr.SrcRange.Filename,
+		Start: hcl.Pos{
+			Line:   objExpr.SrcRange.End.Line,
+			Column: objExpr.SrcRange.End.Column - 1,
+			Byte:   objExpr.SrcRange.End.Byte - 1,
+		},
+		End: objExpr.SrcRange.End,
+	}
+	return f.ReplaceText(closeRange, "\n}") //nolint:wrapcheck
+}
+
+// noticeNonObjectConfig notices that the config checks are skipped, as the config can't be statically analyzed,
+// like when it is a variable or 'jsonencode({...})', without failing the checks of the other topics.
+func (r *MSKTopicConfigRule) noticeNonObjectConfig(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	msg := "config must be an inline object literal for linting: skipping the config checks"
+	if ref, ok := moduleOutputReference(configAttr); ok {
+		msg = fmt.Sprintf(
+			"skipping the config checks, as the config is assigned from the module output '%s' which can't be statically analyzed",
+			ref,
+		)
+	}
+
+	err := emitCategorizedIssue(runner, withSeverity(r, tflint.NOTICE), categoryCorrectness, msg, configAttr.Range)
+	if err != nil {
+		return fmt.Errorf("emitting issue: non object config: %w", err)
+	}
+	return nil
+}
+
+// noticeReferencedConfigValues notices that the checks of the config values referencing a variable or a local,
+// like 'local.one_day_ms', are skipped, as they can't be statically analyzed.
+func (r *MSKTopicConfigRule) noticeReferencedConfigValues(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	for _, pair := range configExpr.ExprMap() {
+		variables := pair.Value.Variables()
+		if len(variables) == 0 {
+			continue
+		}
+
+		var key string
+		diags := gohcl.DecodeExpression(pair.Key, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		msg := fmt.Sprintf(
+			"skipping the checks of the %s value, as it references '%s' which can't be statically analyzed",
+			strings.TrimSpace(key),
+			formatTraversal(variables[0]),
+		)
+		err := emitCategorizedIssue(runner, withSeverity(r, tflint.NOTICE), categoryCorrectness, msg, pair.Value.Range())
+		if err != nil {
+			return fmt.Errorf("emitting issue: referenced config value: %w", err)
+		}
+	}
+	return nil
+}
+
 func (r *MSKTopicConfigRule) validateAndGetConfigAttr(
 	runner tflint.Runner,
 	topic *hclext.Block,
 ) (*hclext.Attribute, error) {
 	configAttr, hasConfig := topic.Body.Attributes["config"]
 	if !hasConfig {
-		err := runner.EmitIssue(
+		err := emitCategorizedIssue(
+			runner,
 			r,
+			categoryCorrectness,
 			"missing config attribute: the topic configuration must be specified in a config attribute",
 			topic.DefRange,
 		)
@@ -148,6 +358,52 @@ func (r *MSKTopicConfigRule) validateAndGetConfigAttr(
 	return configAttr, nil
 }
 
+/*
+moduleOutputReference returns the module output the config is assigned from, like 'module.defaults.topic_config'.
+Its value is only known when planning, so the rules reading the config keys must skip it.
+*/
+func moduleOutputReference(configAttr *hclext.Attribute) (string, bool) {
+	traversal, diags := hcl.AbsTraversalForExpr(configAttr.Expr)
+	if diags.HasErrors() || traversal.RootName() != "module" {
+		return "", false
+	}
+
+	return formatTraversal(traversal), true
+}
+
+// formatTraversal formats a reference like 'local.one_day_ms', eliding the indexes which can't be statically known.
+func formatTraversal(traversal hcl.Traversal) string {
+	var ref strings.Builder
+	for _, step := range traversal {
+		switch step := step.(type) {
+		case hcl.TraverseRoot:
+			ref.WriteString(step.Name)
+		case hcl.TraverseAttr:
+			ref.WriteString("." + step.Name)
+		case hcl.TraverseIndex:
+			ref.WriteString("[...]")
+		}
+	}
+	return ref.String()
+}
+
+// isObjectConfig returns whether the config is an inline object literal, whose keys can be statically checked.
+func isObjectConfig(configAttr *hclext.Attribute) bool {
+	_, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	return ok
+}
+
+// skipNonObjectConfig is used by the rules which only check the config keys, skipping silently the configs
+// which are not inline object literals, like 'jsonencode({...})' or variables, as the msk_topic_config rule
+// already notices them.
+func skipNonObjectConfig(topic *hclext.Block, configAttr *hclext.Attribute) bool {
+	if isObjectConfig(configAttr) {
+		return false
+	}
+	logger.Debug("skipping topic config which is not an object literal", "labels", topic.Labels)
+	return true
+}
+
 func constructConfigKeyToPairMap(configAttr *hclext.Attribute) (map[string]hcl.KeyValuePair, error) {
 	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
 	if !ok {
@@ -161,23 +417,247 @@ func constructConfigKeyToPairMap(configAttr *hclext.Attribute) (map[string]hcl.K
 		if diags.HasErrors() {
 			return nil, diags
 		}
-		res[pk] = pair
+		// the keys padded with whitespace are trimmed by the msk_topic_config rule, so they are checked as trimmed
+		res[strings.TrimSpace(pk)] = pair
 	}
 	return res, nil
 }
 
+/*
+decodeConfigValue decodes the value of a config entry, returning false when it can't be statically analyzed,
+like a reference to 'local.one_day_ms' only known when planning: the checks of its value are then skipped,
+while the checks of its key still apply.
+*/
+func decodeConfigValue(pair hcl.KeyValuePair) (string, bool, error) {
+	if len(pair.Value.Variables()) > 0 {
+		return "", false, nil
+	}
+
+	var val string
+	diags := gohcl.DecodeExpression(pair.Value, nil, &val)
+	if diags.HasErrors() {
+		return "", false, diags
+	}
+	return val, true, nil
+}
+
+/*
+validateConfigKeysDefinedOnce reports the keys that are defined more than once in the config.
+Only the last definition is effective, so reviewers can't easily tell the value applied.
+*/
+func (r *MSKTopicConfigRule) validateConfigKeysDefinedOnce(
+	runner tflint.Runner,
+	configAttr *hclext.Attribute,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	for _, pair := range configExpr.ExprMap() {
+		var key string
+		diags := gohcl.DecodeExpression(pair.Key, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		key = strings.TrimSpace(key)
+		effectivePair := configKeyToPairMap[key]
+		if effectivePair.Key.Range() == pair.Key.Range() {
+			continue
+		}
+
+		effectiveVal, ok, err := decodeConfigValue(effectivePair)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			effectiveVal = formatTraversal(effectivePair.Value.Variables()[0])
+		}
+
+		msg := fmt.Sprintf(
+			"%s is defined more than once: this definition is overridden by the effective value '%s'",
+			key,
+			effectiveVal,
+		)
+		if err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, pair.Key.Range()); err != nil {
+			return fmt.Errorf("emitting issue: config key defined more than once: %w", err)
+		}
+	}
+	return nil
+}
+
+// mergedConfigDefinition is the definition of a config key in an argument of 'merge(...)'.
+type mergedConfigDefinition struct {
+	pair hcl.KeyValuePair
+	// the range to report the definition at: the key in an inline object, or the argument referencing a local
+	rng hcl.Range
+}
+
+/*
+validateMergedConfigKeysDefinedOnce reports the keys of a config built with 'merge(...)' which are overridden by a
+later argument, like a key of a base local redefined by an inline object. Only the arguments which are inline
+objects or references to locals defined as object literals are checked, as the keys of the others can't be
+statically known.
+*/
+func (r *MSKTopicConfigRule) validateMergedConfigKeysDefinedOnce(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	callExpr, ok := configAttr.Expr.(*hclsyntax.FunctionCallExpr)
+	if !ok || callExpr.Name != "merge" {
+		return nil
+	}
+
+	localExprs, err := getLocalExprs(runner)
+	if err != nil {
+		return err
+	}
+
+	var keys []string
+	keyDefinitions := map[string][]mergedConfigDefinition{}
+	for _, arg := range callExpr.Args {
+		var argExpr hcl.Expression = arg
+		var rng hcl.Range
+		if traversal, diags := hcl.AbsTraversalForExpr(arg); !diags.HasErrors() && traversal.RootName() == "local" {
+			if len(traversal) != 2 {
+				continue
+			}
+			localAttr, ok := traversal[1].(hcl.TraverseAttr)
+			if !ok {
+				continue
+			}
+			argExpr, rng = localExprs[localAttr.Name], arg.Range()
+		}
+		objExpr, ok := argExpr.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			logger.Debug("skipping merge argument which is not an object literal", "range", arg.Range())
+			continue
+		}
+
+		for _, pair := range objExpr.ExprMap() {
+			var key string
+			diags := gohcl.DecodeExpression(pair.Key, nil, &key)
+			if diags.HasErrors() {
+				return diags
+			}
+			key = strings.TrimSpace(key)
+			if _, seen := keyDefinitions[key]; !seen {
+				keys = append(keys, key)
+			}
+
+			definition := mergedConfigDefinition{pair: pair, rng: rng}
+			if rng == (hcl.Range{}) {
+				definition.rng = pair.Key.Range()
+			}
+			keyDefinitions[key] = append(keyDefinitions[key], definition)
+		}
+	}
+
+	for _, key := range keys {
+		definitions := keyDefinitions[key]
+		effectivePair := definitions[len(definitions)-1].pair
+		effectiveVal, ok, err := decodeConfigValue(effectivePair)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			effectiveVal = formatTraversal(effectivePair.Value.Variables()[0])
+		}
+
+		for _, definition := range definitions[:len(definitions)-1] {
+			msg := fmt.Sprintf(
+				"%s is defined more than once in merge(): this definition is overridden by the effective value '%s'",
+				key,
+				effectiveVal,
+			)
+			if err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, definition.rng); err != nil {
+				return fmt.Errorf("emitting issue: merged config key defined more than once: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// getLocalExprs returns the expressions of the locals of the module, by name.
+func getLocalExprs(runner tflint.Runner) (map[string]hcl.Expression, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "locals",
+				Body: &hclext.BodySchema{Mode: hclext.SchemaJustAttributesMode},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting locals: %w", err)
+	}
+
+	localExprs := map[string]hcl.Expression{}
+	for _, block := range content.Blocks {
+		for name, attr := range block.Body.Attributes {
+			localExprs[name] = attr.Expr
+		}
+	}
+	return localExprs, nil
+}
+
+/*
+validateConfigKeysTrimmed reports the keys with leading or trailing whitespace, like '"retention.ms "'.
+Kafka takes them as distinct configs, so the intended config is silently not applied.
+*/
+func (r *MSKTopicConfigRule) validateConfigKeysTrimmed(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	for _, pair := range configExpr.ExprMap() {
+		var key string
+		diags := gohcl.DecodeExpression(pair.Key, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		trimmedKey := strings.TrimSpace(key)
+		if trimmedKey == key {
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"config key '%s' has leading or trailing whitespace, which makes it a distinct config from '%s': trimming it...",
+			key,
+			trimmedKey,
+		)
+		keyRange := pair.Key.Range()
+		err := emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, keyRange,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(keyRange, fmt.Sprintf("%q", trimmedKey))
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: config key with whitespace: %w", err)
+		}
+	}
+	return nil
+}
+
 const (
 	replFactorAttrName = "replication_factor"
 	// See [https://github.com/utilitywarehouse/tflint-ruleset-kafka-config/blob/main/rules/msk_topic_config.md#requirements] for explanation.
-	replicationFactorVal = 3
+	replicationFactorDefault = 3
 )
 
-var replFactorFix = fmt.Sprintf("%s = %d", replFactorAttrName, replicationFactorVal)
+func buildReplFactorFix(replicationFactorVal int) string {
+	return fmt.Sprintf("%s = %d", replFactorAttrName, replicationFactorVal)
+}
 
-func (r *MSKTopicConfigRule) validateReplicationFactor(runner tflint.Runner, topic *hclext.Block) error {
+func (r *MSKTopicConfigRule) validateReplicationFactor(
+	runner tflint.Runner,
+	topic *hclext.Block,
+	replicationFactorVal int,
+) error {
 	replFactorAttr, hasReplFactor := topic.Body.Attributes[replFactorAttrName]
 	if !hasReplFactor {
-		return r.reportMissingReplicationFactor(runner, topic)
+		return r.reportMissingReplicationFactor(runner, topic, replicationFactorVal)
 	}
 
 	var replFactor int
@@ -187,12 +667,14 @@ func (r *MSKTopicConfigRule) validateReplicationFactor(runner tflint.Runner, top
 	}
 
 	if replFactor != replicationFactorVal {
-		err := runner.EmitIssueWithFix(
+		err := emitCategorizedIssueWithFix(
+			runner,
 			r,
+			categoryDurability,
 			fmt.Sprintf("the replication_factor must be equal to '%d'", replicationFactorVal),
 			replFactorAttr.Range,
 			func(f tflint.Fixer) error {
-				return f.ReplaceText(replFactorAttr.Range, replFactorFix)
+				return f.ReplaceText(replFactorAttr.Range, buildReplFactorFix(replicationFactorVal))
 			},
 		)
 		if err != nil {
@@ -202,12 +684,18 @@ func (r *MSKTopicConfigRule) validateReplicationFactor(runner tflint.Runner, top
 	return nil
 }
 
-func (r *MSKTopicConfigRule) reportMissingReplicationFactor(runner tflint.Runner, topic *hclext.Block) error {
+func (r *MSKTopicConfigRule) reportMissingReplicationFactor(
+	runner tflint.Runner,
+	topic *hclext.Block,
+	replicationFactorVal int,
+) error {
 	nameAttr, hasName := topic.Body.Attributes["name"]
 	if !hasName {
 		/*	when no name attribute, we can not issue a fix, as we insert the replication factor after the name */
-		err := runner.EmitIssue(
+		err := emitCategorizedIssue(
+			runner,
 			r,
+			categoryDurability,
 			fmt.Sprintf("missing replication_factor: it must be equal to '%d'", replicationFactorVal),
 			topic.DefRange,
 		)
@@ -217,12 +705,14 @@ func (r *MSKTopicConfigRule) reportMissingReplicationFactor(runner tflint.Runner
 		return nil
 	}
 
-	err := runner.EmitIssueWithFix(
+	err := emitCategorizedIssueWithFix(
+		runner,
 		r,
+		categoryDurability,
 		fmt.Sprintf("missing replication_factor: it must be equal to '%d'", replicationFactorVal),
 		topic.DefRange,
 		func(f tflint.Fixer) error {
-			return f.InsertTextAfter(nameAttr.Range, "\n"+replFactorFix)
+			return f.InsertTextAfter(nameAttr.Range, "\n"+buildReplFactorFix(replicationFactorVal))
 		},
 	)
 	if err != nil {
@@ -236,21 +726,60 @@ const (
 	compressionTypeVal = "zstd"
 )
 
-var compressionTypeFix = fmt.Sprintf(`"%s" = "%s"`, compressionTypeKey, compressionTypeVal)
+func buildCompressionTypeFix(compressionType string) string {
+	return fmt.Sprintf(`"%s" = "%s"`, compressionTypeKey, compressionType)
+}
+
+/*
+resolveDefaultCompressionType sets the compression type used by the fixes, when it is not configured:
+'zstd' when it is allowed, otherwise the first allowed compression type.
+*/
+func resolveDefaultCompressionType(ruleConfig *mskTopicConfigRuleConfig) error {
+	if len(ruleConfig.AllowedCompressionTypes) == 0 {
+		return fmt.Errorf("allowed_compression_types must contain at least one compression type")
+	}
+	if ruleConfig.DefaultCompressionType == "" {
+		ruleConfig.DefaultCompressionType = ruleConfig.AllowedCompressionTypes[0]
+		if slices.Contains(ruleConfig.AllowedCompressionTypes, compressionTypeVal) {
+			ruleConfig.DefaultCompressionType = compressionTypeVal
+		}
+		return nil
+	}
+	if !slices.Contains(ruleConfig.AllowedCompressionTypes, ruleConfig.DefaultCompressionType) {
+		return fmt.Errorf(
+			"default_compression_type '%s' must be one of the allowed_compression_types %v",
+			ruleConfig.DefaultCompressionType,
+			ruleConfig.AllowedCompressionTypes,
+		)
+	}
+	return nil
+}
+
+// describeAllowedCompressionTypes returns the requirement on the compression type, used in the issue messages.
+func describeAllowedCompressionTypes(allowed []string) string {
+	if len(allowed) == 1 {
+		return fmt.Sprintf("equal to '%s'", allowed[0])
+	}
+	return fmt.Sprintf("one of '%s'", strings.Join(allowed, "', '"))
+}
 
 func (r *MSKTopicConfigRule) validateCompressionType(
 	runner tflint.Runner,
 	config *hclext.Attribute,
 	configPairMap map[string]hcl.KeyValuePair,
+	ruleConfig mskTopicConfigRuleConfig,
 ) error {
+	allowedDesc := describeAllowedCompressionTypes(ruleConfig.AllowedCompressionTypes)
 	ctPair, hasCt := configPairMap[compressionTypeKey]
 	if !hasCt {
-		err := runner.EmitIssueWithFix(
+		err := emitCategorizedIssueWithFix(
+			runner,
 			r,
-			fmt.Sprintf("missing %s: it must be equal to '%s'", compressionTypeKey, compressionTypeVal),
+			categoryCost,
+			fmt.Sprintf("missing %s: it must be %s", compressionTypeKey, allowedDesc),
 			config.Range,
 			func(f tflint.Fixer) error {
-				return f.InsertTextAfter(config.Expr.StartRange(), "\n"+compressionTypeFix)
+				return insertConfigEntry(f, config, buildCompressionTypeFix(ruleConfig.DefaultCompressionType))
 			},
 		)
 		if err != nil {
@@ -259,19 +788,20 @@ func (r *MSKTopicConfigRule) validateCompressionType(
 		return nil
 	}
 
-	var ctVal string
-	diags := gohcl.DecodeExpression(ctPair.Value, nil, &ctVal)
-	if diags.HasErrors() {
-		return diags
+	ctVal, ok, err := decodeConfigValue(ctPair)
+	if err != nil || !ok {
+		return err
 	}
 
-	if ctVal != compressionTypeVal {
-		err := runner.EmitIssueWithFix(
+	if !slices.Contains(ruleConfig.AllowedCompressionTypes, ctVal) {
+		err := emitCategorizedIssueWithFix(
+			runner,
 			r,
-			fmt.Sprintf("the %s value must be equal to '%s'", compressionTypeKey, compressionTypeVal),
+			categoryCost,
+			fmt.Sprintf("the %s value must be %s", compressionTypeKey, allowedDesc),
 			ctPair.Value.Range(),
 			func(f tflint.Fixer) error {
-				return f.ReplaceText(ctPair.Value.Range(), `"`+compressionTypeVal+`"`)
+				return f.ReplaceText(ctPair.Value.Range(), `"`+ruleConfig.DefaultCompressionType+`"`)
 			},
 		)
 		if err != nil {
@@ -282,11 +812,71 @@ func (r *MSKTopicConfigRule) validateCompressionType(
 	return nil
 }
 
+const minInSyncReplicasKey = "min.insync.replicas"
+
+func buildMinInSyncReplicasFix(minInSyncReplicas int) string {
+	return fmt.Sprintf(`"%s" = "%d"`, minInSyncReplicasKey, minInSyncReplicas)
+}
+
+// validateMinInSyncReplicas checks that min.insync.replicas is one less than the replication factor,
+// so a write is acknowledged by all but one of the replicas, and at least 1 with a single replica.
+func (r *MSKTopicConfigRule) validateMinInSyncReplicas(
+	runner tflint.Runner,
+	config *hclext.Attribute,
+	configPairMap map[string]hcl.KeyValuePair,
+	replicationFactorVal int,
+) error {
+	// kafka rejects a min.insync.replicas lower than 1, which a single replica must satisfy on its own
+	expected := max(1, replicationFactorVal-1)
+
+	misrPair, hasMisr := configPairMap[minInSyncReplicasKey]
+	if !hasMisr {
+		err := emitCategorizedIssueWithFix(
+			runner,
+			r,
+			categoryDurability,
+			fmt.Sprintf("missing %s: it must be equal to '%d'", minInSyncReplicasKey, expected),
+			config.Range,
+			func(f tflint.Fixer) error {
+				return insertConfigEntry(f, config, buildMinInSyncReplicasFix(expected))
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue with fix: no min insync replicas: %w", err)
+		}
+		return nil
+	}
+
+	misrVal, ok, err := decodeConfigValue(misrPair)
+	if err != nil || !ok {
+		return err
+	}
+
+	if misrVal != strconv.Itoa(expected) {
+		err := emitCategorizedIssueWithFix(
+			runner,
+			r,
+			categoryDurability,
+			fmt.Sprintf("the %s value must be equal to '%d'", minInSyncReplicasKey, expected),
+			misrPair.Value.Range(),
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(misrPair.Value.Range(), fmt.Sprintf(`"%d"`, expected))
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue with fix: wrong min insync replicas: %w", err)
+		}
+	}
+	return nil
+}
+
 const (
 	cleanupPolicyKey     = "cleanup.policy"
 	cleanupPolicyDelete  = "delete"
 	cleanupPolicyCompact = "compact"
-	cleanupPolicyDefault = cleanupPolicyDelete
+	// cleanupPolicyCompactDelete compacts the topic and deletes the segments older than the retention time.
+	cleanupPolicyCompactDelete = cleanupPolicyCompact + cleanupPolicySeparator + cleanupPolicyDelete
+	cleanupPolicyDefault       = cleanupPolicyDelete
 )
 
 var (
@@ -301,12 +891,14 @@ func (r *MSKTopicConfigRule) getAndValidateCleanupPolicyValue(
 ) (string, error) {
 	cpPair, hasCp := configKeyToPairMap[cleanupPolicyKey]
 	if !hasCp {
-		err := runner.EmitIssueWithFix(
+		err := emitCategorizedIssueWithFix(
+			runner,
 			r,
+			categoryCorrectness,
 			fmt.Sprintf("missing %s: using default '%s'", cleanupPolicyKey, cleanupPolicyDefault),
 			config.Range,
 			func(f tflint.Fixer) error {
-				return f.InsertTextAfter(config.Expr.StartRange(), "\n"+cleanupPolicyDefaultFix)
+				return insertConfigEntry(f, config, cleanupPolicyDefaultFix)
 			},
 		)
 		if err != nil {
@@ -315,14 +907,24 @@ func (r *MSKTopicConfigRule) getAndValidateCleanupPolicyValue(
 		return cleanupPolicyDefault, nil
 	}
 
-	var cpVal string
-	diags := gohcl.DecodeExpression(cpPair.Value, nil, &cpVal)
-	if diags.HasErrors() {
-		return "", diags
+	cpVal, ok, err := decodeConfigValue(cpPair)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	cpVal, err = r.collapseRedundantCleanupPolicy(runner, cpPair, cpVal)
+	if err != nil {
+		return "", err
 	}
+	if isCompactDeletePolicy(cpVal) {
+		return cleanupPolicyCompactDelete, nil
+	}
+
 	if !slices.Contains(cleanupPolicyValidValues, cpVal) {
-		err := runner.EmitIssue(
+		err := emitCategorizedIssue(
+			runner,
 			r,
+			categoryCorrectness,
 			fmt.Sprintf(
 				"invalid %s: it must be one of [%s], but currently is '%s'",
 				cleanupPolicyKey,
@@ -339,36 +941,122 @@ func (r *MSKTopicConfigRule) getAndValidateCleanupPolicyValue(
 	return cpVal, nil
 }
 
+const cleanupPolicySeparator = ","
+
+// cleanupPolicyTokens splits a cleanup policy into its policies, trimming the spaces around them, like in "compact, delete".
+func cleanupPolicyTokens(cpVal string) []string {
+	tokens := strings.Split(cpVal, cleanupPolicySeparator)
+	for i, token := range tokens {
+		tokens[i] = strings.TrimSpace(token)
+	}
+	return tokens
+}
+
+/*
+hasDeletePolicy returns whether the topic has the delete cleanup policy, including when the policy is missing, as it is
+then fixed to delete, or repeated, like "delete,delete". It doesn't report anything, as the policy is validated later.
+*/
+func hasDeletePolicy(configKeyToPairMap map[string]hcl.KeyValuePair) (bool, error) {
+	cpPair, ok := configKeyToPairMap[cleanupPolicyKey]
+	if !ok {
+		return true, nil
+	}
+	cpVal, ok, err := decodeConfigValue(cpPair)
+	if err != nil || !ok {
+		return false, err
+	}
+	for _, token := range cleanupPolicyTokens(cpVal) {
+		if token != cleanupPolicyDelete {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// collapseRedundantCleanupPolicy reports a value like "delete,delete" repeating the same policy, which is redundant,
+// proposing to collapse it to the unique policies.
+func (r *MSKTopicConfigRule) collapseRedundantCleanupPolicy(
+	runner tflint.Runner,
+	cpPair hcl.KeyValuePair,
+	cpVal string,
+) (string, error) {
+	tokens := cleanupPolicyTokens(cpVal)
+	var uniqueTokens []string
+	for _, token := range tokens {
+		if !slices.Contains(uniqueTokens, token) {
+			uniqueTokens = append(uniqueTokens, token)
+		}
+	}
+
+	if len(uniqueTokens) == len(tokens) {
+		return cpVal, nil
+	}
+
+	collapsedVal := strings.Join(uniqueTokens, cleanupPolicySeparator)
+	msg := fmt.Sprintf(
+		"redundant %s: '%s' repeats the same policy, collapsing it to '%s'",
+		cleanupPolicyKey,
+		cpVal,
+		collapsedVal,
+	)
+	err := emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, cpPair.Value.Range(),
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(cpPair.Value.Range(), `"`+collapsedVal+`"`)
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("emitting issue: redundant cleanup policy: %w", err)
+	}
+	return collapsedVal, nil
+}
+
+// isCompactDeletePolicy returns whether the policy combines compaction and deletion, in any order.
+func isCompactDeletePolicy(cpVal string) bool {
+	tokens := cleanupPolicyTokens(cpVal)
+	slices.Sort(tokens)
+	return slices.Equal(tokens, []string{cleanupPolicyCompact, cleanupPolicyDelete})
+}
+
 const (
 	retentionTimeAttr = "retention.ms"
-	// The threshold on retention time when remote storage is supported.
-	tieredStorageThresholdInDays    = 3
-	tieredStorageEnableAttr         = "remote.storage.enable"
-	tieredStorageEnabledValue       = "true"
-	localRetentionTimeAttr          = "local.retention.ms"
-	localRetentionTimeMillisDefault = 1 * millisInOneDay
-	localRetentionTimeCommentBase   = "keep data in primary storage"
+	// The default threshold on retention time from which remote storage is required, overridable in the rule config.
+	tieredStorageThresholdInDaysDefault = 3
+	tieredStorageEnableAttr             = "remote.storage.enable"
+	tieredStorageEnabledValue           = "true"
+	localRetentionTimeAttr              = "local.retention.ms"
+	localRetentionTimeInDaysDefault     = 1
+	// Shared with the comments rule, so the comment inserted by the fix always satisfies it.
+	localRetentionTimeCommentBase = "keep data in primary storage"
+	// Shared with the comments rule, so the stale comments are removed with the retention time.
+	retentionTimeCommentBase = "keep data"
 )
 
-/*	Putting an invalid value by default to force users to put a valid value */
+// configValuePlaceholder is the invalid value put by the fixes, to force users to put a valid value.
+// The comments rule skips it, as it is already reported by this rule.
+const configValuePlaceholder = "???"
+
 var (
-	retentionTimeDefTemplate = fmt.Sprintf(`"%s" = "???"`, retentionTimeAttr)
+	retentionTimeDefTemplate = fmt.Sprintf(`"%s" = "%s"`, retentionTimeAttr, configValuePlaceholder)
 	enableTieredStorage      = fmt.Sprintf(`"%s" = "%s"`, tieredStorageEnableAttr, tieredStorageEnabledValue)
+)
+
+func buildLocalRetentionTimeFix(localRetentionTimeMillis int, unitWords map[string]string, commentBase string) string {
 	/* putting the comment after the property definition. */
-	localRetentionTimeFix = fmt.Sprintf(
+	return fmt.Sprintf(
 		`"%s" = "%d" %s`,
 		localRetentionTimeAttr,
-		localRetentionTimeMillisDefault,
-		buildCommentForMillis(localRetentionTimeMillisDefault, localRetentionTimeCommentBase),
+		localRetentionTimeMillis,
+		buildCommentForMillis(localRetentionTimeMillis, commentBase, unitWords),
 	)
-)
+}
 
 func (r *MSKTopicConfigRule) validateRetentionForDeletePolicy(
 	runner tflint.Runner,
 	config *hclext.Attribute,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	ruleConfig mskTopicConfigRuleConfig,
 ) error {
-	retentionTime, err := r.getAndValidateRetentionTime(runner, config, configKeyToPairMap)
+	retentionTime, err := r.getAndValidateRetentionTime(runner, config, configKeyToPairMap, ruleConfig.MaxRetentionMs)
 	if err != nil {
 		return err
 	}
@@ -377,21 +1065,34 @@ func (r *MSKTopicConfigRule) validateRetentionForDeletePolicy(
 		return nil
 	}
 
-	if mustEnableTieredStorage(*retentionTime) {
-		if err := r.validateTieredStorageEnabled(runner, config, configKeyToPairMap); err != nil {
+	if mustEnableTieredStorage(*retentionTime, ruleConfig.TieredStorageThresholdDays) {
+		if err := r.validateTieredStorageEnabled(
+			runner,
+			config,
+			configKeyToPairMap,
+			ruleConfig.TieredStorageThresholdDays,
+		); err != nil {
 			return err
 		}
 
-		if err := r.validateLocalRetentionDefined(runner, config, configKeyToPairMap); err != nil {
+		localRetentionTimeMillisDefault := ruleConfig.DefaultLocalRetentionDays * millisInOneDay
+		if err := r.validateLocalRetentionDefined(
+			runner,
+			config,
+			configKeyToPairMap,
+			localRetentionTimeMillisDefault,
+			ruleConfig.timeUnitWords,
+			baseCommentFor(localRetentionTimeAttr, ruleConfig.baseComments),
+		); err != nil {
 			return err
 		}
 	} else {
-		reason := fmt.Sprintf("less than %d days retention", tieredStorageThresholdInDays)
+		reason := fmt.Sprintf("less than %d days retention", ruleConfig.TieredStorageThresholdDays)
 		if err := r.validateTieredStorageDisabled(runner, configKeyToPairMap, reason); err != nil {
 			return err
 		}
 
-		if err := r.validateLocalRetentionNotDefined(runner, configKeyToPairMap, reason); err != nil {
+		if err := r.validateLocalRetentionNotDefined(runner, configKeyToPairMap, ruleConfig.baseComments, reason); err != nil {
 			return err
 		}
 	}
@@ -399,14 +1100,17 @@ func (r *MSKTopicConfigRule) validateRetentionForDeletePolicy(
 	return nil
 }
 
-func mustEnableTieredStorage(retentionTime int) bool {
-	return retentionTime >= tieredStorageThresholdInDays*millisInOneDay || isInfiniteRetention(retentionTime)
+func mustEnableTieredStorage(retentionTime int, thresholdDays int) bool {
+	return retentionTime >= thresholdDays*millisInOneDay || isInfiniteRetention(retentionTime)
 }
 
 func (r *MSKTopicConfigRule) validateLocalRetentionDefined(
 	runner tflint.Runner,
 	config *hclext.Attribute,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	localRetentionTimeMillisDefault int,
+	unitWords map[string]string,
+	commentBase string,
 ) error {
 	localRetTimePair, hasLocalRetTimeAttr := configKeyToPairMap[localRetentionTimeAttr]
 	if !hasLocalRetTimeAttr {
@@ -415,9 +1119,9 @@ func (r *MSKTopicConfigRule) validateLocalRetentionDefined(
 			localRetentionTimeAttr,
 			localRetentionTimeMillisDefault,
 		)
-		err := runner.EmitIssueWithFix(r, msg, config.Range,
+		err := emitCategorizedIssueWithFix(runner, r, categoryCost, msg, config.Range,
 			func(f tflint.Fixer) error {
-				return f.InsertTextAfter(config.Expr.StartRange(), "\n"+localRetentionTimeFix)
+				return insertConfigEntry(f, config, buildLocalRetentionTimeFix(localRetentionTimeMillisDefault, unitWords, commentBase))
 			},
 		)
 		if err != nil {
@@ -426,19 +1130,18 @@ func (r *MSKTopicConfigRule) validateLocalRetentionDefined(
 		return nil
 	}
 
-	var localRetTimeVal string
-	diags := gohcl.DecodeExpression(localRetTimePair.Value, nil, &localRetTimeVal)
-	if diags.HasErrors() {
-		return diags
+	localRetTimeVal, ok, err := decodeConfigValue(localRetTimePair)
+	if err != nil || !ok {
+		return err
 	}
 
-	_, err := strconv.Atoi(localRetTimeVal)
+	_, err = strconv.Atoi(localRetTimeVal)
 	if err != nil {
 		msg := fmt.Sprintf(
 			"%s must have a valid integer value expressed in milliseconds",
 			localRetentionTimeAttr,
 		)
-		err := runner.EmitIssue(r, msg, localRetTimePair.Value.Range())
+		err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, localRetTimePair.Value.Range())
 		if err != nil {
 			return fmt.Errorf("emitting issue: invalid local retention time: %w", err)
 		}
@@ -451,6 +1154,7 @@ func (r *MSKTopicConfigRule) validateLocalRetentionDefined(
 func (r *MSKTopicConfigRule) validateLocalRetentionNotDefined(
 	runner tflint.Runner,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	baseComments map[string]string,
 	reason string,
 ) error {
 	localRetTimePair, hasLocalRetTimeAttr := configKeyToPairMap[localRetentionTimeAttr]
@@ -463,17 +1167,14 @@ func (r *MSKTopicConfigRule) validateLocalRetentionNotDefined(
 		localRetentionTimeAttr,
 		reason,
 	)
-	err := runner.EmitIssueWithFix(r, msg, localRetTimePair.Value.Range(),
+	comment, err := getExistingComment(runner, localRetTimePair)
+	if err != nil {
+		return err
+	}
+
+	err = emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, localRetTimePair.Value.Range(),
 		func(f tflint.Fixer) error {
-			/* remove the whole key + value */
-			keyRange := localRetTimePair.Key.Range()
-			return f.Remove(
-				hcl.Range{
-					Filename: keyRange.Filename,
-					Start:    keyRange.Start,
-					End:      localRetTimePair.Value.Range().End,
-				},
-			)
+			return removeConfigPair(f, localRetTimePair, comment, baseCommentFor(localRetentionTimeAttr, baseComments))
 		},
 	)
 	if err != nil {
@@ -490,17 +1191,18 @@ func (r *MSKTopicConfigRule) validateTieredStorageEnabled(
 	runner tflint.Runner,
 	config *hclext.Attribute,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	thresholdDays int,
 ) error {
 	tieredStoragePair, hasTieredStorageAttr := configKeyToPairMap[tieredStorageEnableAttr]
 	tieredStorageEnableMsg := fmt.Sprintf(
 		"tiered storage must be enabled when retention time is longer than %d days",
-		tieredStorageThresholdInDays,
+		thresholdDays,
 	)
 
 	if !hasTieredStorageAttr {
-		err := runner.EmitIssueWithFix(r, tieredStorageEnableMsg, config.Range,
+		err := emitCategorizedIssueWithFix(runner, r, categoryCost, tieredStorageEnableMsg, config.Range,
 			func(f tflint.Fixer) error {
-				return f.InsertTextAfter(config.Expr.StartRange(), "\n"+enableTieredStorage)
+				return insertConfigEntry(f, config, enableTieredStorage)
 			},
 		)
 		if err != nil {
@@ -509,14 +1211,13 @@ func (r *MSKTopicConfigRule) validateTieredStorageEnabled(
 		return nil
 	}
 
-	var tieredStorageVal string
-	diags := gohcl.DecodeExpression(tieredStoragePair.Value, nil, &tieredStorageVal)
-	if diags.HasErrors() {
-		return diags
+	tieredStorageVal, ok, err := decodeConfigValue(tieredStoragePair)
+	if err != nil || !ok {
+		return err
 	}
 
 	if tieredStorageVal != tieredStorageEnabledValue {
-		err := runner.EmitIssueWithFix(r, tieredStorageEnableMsg, tieredStoragePair.Value.Range(),
+		err := emitCategorizedIssueWithFix(runner, r, categoryCost, tieredStorageEnableMsg, tieredStoragePair.Value.Range(),
 			func(f tflint.Fixer) error {
 				return f.ReplaceText(tieredStoragePair.Value.Range(), fmt.Sprintf(`"%s"`, tieredStorageEnabledValue))
 			},
@@ -540,10 +1241,9 @@ func (r *MSKTopicConfigRule) validateTieredStorageDisabled(
 		return nil
 	}
 
-	var tieredStorageVal string
-	diags := gohcl.DecodeExpression(tieredStoragePair.Value, nil, &tieredStorageVal)
-	if diags.HasErrors() {
-		return diags
+	tieredStorageVal, ok, err := decodeConfigValue(tieredStoragePair)
+	if err != nil || !ok {
+		return err
 	}
 
 	if tieredStorageVal != tieredStorageEnabledValue {
@@ -554,7 +1254,7 @@ func (r *MSKTopicConfigRule) validateTieredStorageDisabled(
 		"tiered storage is not supported for %s: disabling it...",
 		reason,
 	)
-	err := runner.EmitIssueWithFix(r, msg, tieredStoragePair.Value.Range(),
+	err = emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, tieredStoragePair.Value.Range(),
 		func(f tflint.Fixer) error {
 			/* remove the whole key + value */
 			keyRange := tieredStoragePair.Key.Range()
@@ -578,13 +1278,14 @@ func (r *MSKTopicConfigRule) getAndValidateRetentionTime(
 	runner tflint.Runner,
 	config *hclext.Attribute,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	maxRetentionMs int,
 ) (*int, error) {
 	retTimePair, hasRetTime := configKeyToPairMap[retentionTimeAttr]
 	if !hasRetTime {
 		msg := fmt.Sprintf("%s must be defined on a topic with cleanup policy delete", retentionTimeAttr)
-		err := runner.EmitIssueWithFix(r, msg, config.Range,
+		err := emitCategorizedIssueWithFix(runner, r, categoryDurability, msg, config.Range,
 			func(f tflint.Fixer) error {
-				return f.InsertTextAfter(config.Expr.StartRange(), "\n"+retentionTimeDefTemplate)
+				return insertConfigEntry(f, config, retentionTimeDefTemplate)
 			},
 		)
 		if err != nil {
@@ -593,30 +1294,153 @@ func (r *MSKTopicConfigRule) getAndValidateRetentionTime(
 		return nil, nil
 	}
 
-	var retTimeVal string
-	diags := gohcl.DecodeExpression(retTimePair.Value, nil, &retTimeVal)
-	if diags.HasErrors() {
-		return nil, diags
+	retTimeVal, ok, err := decodeConfigValue(retTimePair)
+	if err != nil || !ok {
+		return nil, err
 	}
 
 	retTimeIntVal, err := strconv.Atoi(retTimeVal)
 	if err != nil {
+		if retTimeFloatVal, isFloat := parseFiniteFloat(retTimeVal); isFloat {
+			return r.reportFloatRetentionTime(runner, retTimePair, retTimeVal, retTimeFloatVal)
+		}
+
 		msg := fmt.Sprintf(
 			"%s must have a valid integer value expressed in milliseconds. Use -1 for infinite retention",
 			retentionTimeAttr,
 		)
-		err := runner.EmitIssue(r, msg, retTimePair.Value.Range())
+		err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, retTimePair.Value.Range())
 		if err != nil {
 			return nil, fmt.Errorf("emitting issue: invalid retention time: %w", err)
 		}
 		return nil, nil
 	}
+
+	if err := r.validateMaxRetentionTime(runner, retTimePair, retTimeIntVal, maxRetentionMs); err != nil {
+		return nil, err
+	}
+	return &retTimeIntVal, nil
+}
+
+// validateMaxRetentionTime checks that a finite retention time doesn't exceed the configured ceiling, if any.
+func (r *MSKTopicConfigRule) validateMaxRetentionTime(
+	runner tflint.Runner,
+	retTimePair hcl.KeyValuePair,
+	retTime int,
+	maxRetentionMs int,
+) error {
+	if maxRetentionMs <= 0 || isInfiniteRetention(retTime) || retTime <= maxRetentionMs {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"%s '%d' (%s) exceeds the maximum retention '%d' (%s)",
+		retentionTimeAttr,
+		retTime,
+		formatMillis(retTime, nil),
+		maxRetentionMs,
+		formatMillis(maxRetentionMs, nil),
+	)
+	if err := emitCategorizedIssue(runner, r, categoryCost, msg, retTimePair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: retention time above maximum: %w", err)
+	}
+	return nil
+}
+
+func parseFiniteFloat(val string) (float64, bool) {
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil || math.IsInf(floatVal, 0) || math.IsNaN(floatVal) {
+		return 0, false
+	}
+	return floatVal, true
+}
+
+/*
+reportFloatRetentionTime reports a retention time formatted as a float, like '86400000.0'.
+When it is a whole number, it is fixed to the integer form and returned, so the other checks can use it.
+*/
+func (r *MSKTopicConfigRule) reportFloatRetentionTime(
+	runner tflint.Runner,
+	retTimePair hcl.KeyValuePair,
+	retTimeVal string,
+	retTimeFloatVal float64,
+) (*int, error) {
+	if retTimeFloatVal != math.Trunc(retTimeFloatVal) {
+		msg := fmt.Sprintf(
+			"%s must have an integer value expressed in milliseconds, but '%s' has a fractional part",
+			retentionTimeAttr,
+			retTimeVal,
+		)
+		err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, retTimePair.Value.Range())
+		if err != nil {
+			return nil, fmt.Errorf("emitting issue: fractional retention time: %w", err)
+		}
+		return nil, nil
+	}
+
+	retTimeIntVal := int(retTimeFloatVal)
+	msg := fmt.Sprintf(
+		"%s must have an integer value expressed in milliseconds: converting '%s' to '%d'",
+		retentionTimeAttr,
+		retTimeVal,
+		retTimeIntVal,
+	)
+	err := emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, retTimePair.Value.Range(),
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(retTimePair.Value.Range(), fmt.Sprintf(`"%d"`, retTimeIntVal))
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("emitting issue: float retention time: %w", err)
+	}
 	return &retTimeIntVal, nil
 }
 
+const (
+	minCompactionLagAttr = "min.compaction.lag.ms"
+	maxCompactionLagAttr = "max.compaction.lag.ms"
+)
+
+// validateCompactionLags reports a minimum compaction lag greater than the maximum one, which the brokers reject.
+func (r *MSKTopicConfigRule) validateCompactionLags(
+	runner tflint.Runner,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+) error {
+	minLagPair, hasMinLag := configKeyToPairMap[minCompactionLagAttr]
+	maxLagPair, hasMaxLag := configKeyToPairMap[maxCompactionLagAttr]
+	if !hasMinLag || !hasMaxLag {
+		return nil
+	}
+
+	minLag, ok, err := decodeIntValue(minLagPair)
+	if err != nil || !ok {
+		return err
+	}
+	maxLag, ok, err := decodeIntValue(maxLagPair)
+	if err != nil || !ok {
+		return err
+	}
+	if minLag <= maxLag {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"%s '%d' must not be greater than %s '%d', as the brokers reject such a config",
+		minCompactionLagAttr,
+		minLag,
+		maxCompactionLagAttr,
+		maxLag,
+	)
+	if err := emitCategorizedIssue(runner, r, categoryCorrectness, msg, minLagPair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: min compaction lag greater than max: %w", err)
+	}
+	return nil
+}
+
 func (r *MSKTopicConfigRule) validateRetentionTimeNotDefined(
 	runner tflint.Runner,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	baseComments map[string]string,
 	reason string,
 ) error {
 	retTimePair, hasRetTime := configKeyToPairMap[retentionTimeAttr]
@@ -624,17 +1448,15 @@ func (r *MSKTopicConfigRule) validateRetentionTimeNotDefined(
 		return nil
 	}
 	msg := fmt.Sprintf("defining %s is misleading for %s: removing it...", retentionTimeAttr, reason)
-	keyRange := retTimePair.Key.Range()
 
-	err := runner.EmitIssueWithFix(r, msg, keyRange,
+	comment, err := getExistingComment(runner, retTimePair)
+	if err != nil {
+		return err
+	}
+
+	err = emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, retTimePair.Key.Range(),
 		func(f tflint.Fixer) error {
-			return f.Remove(
-				hcl.Range{
-					Filename: keyRange.Filename,
-					Start:    keyRange.Start,
-					End:      retTimePair.Value.Range().End,
-				},
-			)
+			return removeConfigPair(f, retTimePair, comment, baseCommentFor(retentionTimeAttr, baseComments))
 		},
 	)
 	if err != nil {
@@ -642,3 +1464,76 @@ func (r *MSKTopicConfigRule) validateRetentionTimeNotDefined(
 	}
 	return nil
 }
+
+const (
+	deleteRetentionTimeAttr = "delete.retention.ms"
+	// Shared with the comments rule, so the stale comments are removed with the tombstones retention time.
+	deleteRetentionTimeCommentBase = "keep tombstones"
+)
+
+// validateDeleteRetentionNotDefined removes the retention of the tombstones, which only applies to compacted topics.
+func (r *MSKTopicConfigRule) validateDeleteRetentionNotDefined(
+	runner tflint.Runner,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+	baseComments map[string]string,
+) error {
+	delRetTimePair, hasDelRetTime := configKeyToPairMap[deleteRetentionTimeAttr]
+	if !hasDelRetTime {
+		return nil
+	}
+	msg := fmt.Sprintf("defining %s is misleading for %s policy: removing it...", deleteRetentionTimeAttr, cleanupPolicyDelete)
+
+	comment, err := getExistingComment(runner, delRetTimePair)
+	if err != nil {
+		return err
+	}
+
+	err = emitCategorizedIssueWithFix(runner, r, categoryCorrectness, msg, delRetTimePair.Key.Range(),
+		func(f tflint.Fixer) error {
+			return removeConfigPair(f, delRetTimePair, comment, baseCommentFor(deleteRetentionTimeAttr, baseComments))
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: delete retention time defined for delete policy: %w", err)
+	}
+	return nil
+}
+
+// removeConfigPair removes the key and value of a config pair, with its comment which is stale once the pair is removed:
+// the comment on the same line is always removed, while the comment on the previous line is only removed when it
+// describes the value, starting like '# <commentBase> for'.
+func removeConfigPair(f tflint.Fixer, pair hcl.KeyValuePair, comment *hclsyntax.Token, commentBase string) error {
+	keyRange := pair.Key.Range()
+	removeRange := hcl.Range{
+		Filename: keyRange.Filename,
+		Start:    keyRange.Start,
+		End:      pair.Value.Range().End,
+	}
+	if comment == nil {
+		return f.Remove(removeRange) //nolint:wrapcheck
+	}
+
+	if comment.Range.Start.Line == keyRange.Start.Line {
+		// keep the newline ending the comment, like for a key without comment
+		removeRange.End = comment.Range.End
+		if bytes.HasSuffix(comment.Bytes, []byte("\n")) {
+			removeRange.End.Byte--
+		}
+		return f.Remove(removeRange) //nolint:wrapcheck
+	}
+	if isValueComment(comment, commentBase) {
+		if err := f.Remove(comment.Range); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+	return f.Remove(removeRange) //nolint:wrapcheck
+}
+
+// isValueComment returns whether the comment describes a value, like '# keep data for 1 day' for the base 'keep data'.
+func isValueComment(comment *hclsyntax.Token, commentBase string) bool {
+	commentTxt := strings.TrimSpace(string(comment.Bytes))
+	if normalized, ok := normalizeCommentSpacing(commentTxt); ok {
+		commentTxt = normalized
+	}
+	return strings.HasPrefix(commentTxt, "# "+commentBase+" for")
+}
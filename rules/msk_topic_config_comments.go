@@ -8,13 +8,124 @@ import (
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
-	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
+type mskTopicConfigCommentsRuleConfig struct {
+	UnitWords     map[string]string `hclext:"unit_words,optional"`
+	BaseComments  map[string]string `hclext:"base_comments,optional"`
+	ByteUnitStyle string            `hclext:"byte_unit_style,optional"`
+}
+
+// timeUnitKeys are the units of the human-readable times, which the unit_words config can localize.
+var timeUnitKeys = []string{"hour", "hours", "day", "days", "week", "weeks", "month", "months", "year", "years"}
+
+// getTimeUnitWords returns the words of the time units used in the comments, or nil for the default English ones.
+// It is shared with the config rule, so the comments inserted by its fixes use the same words.
+func getTimeUnitWords(runner tflint.Runner) (map[string]string, error) {
+	var ruleConfig mskTopicConfigCommentsRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicConfigCommentsRule{}).Name(), &ruleConfig); err != nil {
+		return nil, fmt.Errorf("decoding rule config: %w", err)
+	}
+	if len(ruleConfig.UnitWords) == 0 {
+		return nil, nil
+	}
+
+	missingUnits := slices.DeleteFunc(slices.Clone(timeUnitKeys), func(unit string) bool {
+		return ruleConfig.UnitWords[unit] != ""
+	})
+	if len(missingUnits) > 0 {
+		return nil, fmt.Errorf(
+			"unit_words must define the words of all the units %v, but misses %v",
+			timeUnitKeys,
+			missingUnits,
+		)
+	}
+	return ruleConfig.UnitWords, nil
+}
+
+// getBaseComments returns the base comments overriding the default ones by config key, like 'retain data' for
+// 'retention.ms'. Like the unit words, they are shared with the config rule, for the comments its fixes insert
+// and remove.
+func getBaseComments(runner tflint.Runner) (map[string]string, error) {
+	var ruleConfig mskTopicConfigCommentsRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicConfigCommentsRule{}).Name(), &ruleConfig); err != nil {
+		return nil, fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	commentedKeys := make([]string, 0, len(configTimeValueCommentInfos)+len(configByteValueCommentInfos))
+	for _, info := range slices.Concat(configTimeValueCommentInfos, configByteValueCommentInfos) {
+		commentedKeys = append(commentedKeys, info.key)
+	}
+	for key, baseComment := range ruleConfig.BaseComments {
+		if !slices.Contains(commentedKeys, key) {
+			return nil, fmt.Errorf(
+				"base_comments can only override the comments of the keys %v, but defines '%s'",
+				commentedKeys,
+				key,
+			)
+		}
+		if strings.TrimSpace(baseComment) == "" {
+			return nil, fmt.Errorf("base_comments must not define an empty comment for '%s'", key)
+		}
+	}
+	return ruleConfig.BaseComments, nil
+}
+
+// baseCommentFor returns the base comment of the key, either overridden in baseComments or the default one.
+func baseCommentFor(key string, baseComments map[string]string) string {
+	if baseComment, ok := baseComments[key]; ok {
+		return baseComment
+	}
+	for _, info := range slices.Concat(configTimeValueCommentInfos, configByteValueCommentInfos) {
+		if info.key == key {
+			return info.baseComment
+		}
+	}
+	return ""
+}
+
+// withBaseComments returns a copy of the infos, with their base comment overridden by baseComments.
+func withBaseComments(infos []configValueCommentInfo, baseComments map[string]string) []configValueCommentInfo {
+	res := slices.Clone(infos)
+	for i := range res {
+		res[i].baseComment = baseCommentFor(res[i].key, baseComments)
+	}
+	return res
+}
+
+// byteUnitStyle is the style of the units of the human-readable data sizes.
+type byteUnitStyle string
+
+const (
+	// byteUnitStyleIEC uses the powers of 1024, like 'MiB'.
+	byteUnitStyleIEC byteUnitStyle = "iec"
+	// byteUnitStyleSI uses the powers of 1000, like 'MB'.
+	byteUnitStyleSI byteUnitStyle = "si"
+)
+
+// getByteUnitStyle returns the style of the byte units used in the comments, which is IEC by default.
+func getByteUnitStyle(runner tflint.Runner) (byteUnitStyle, error) {
+	ruleConfig := mskTopicConfigCommentsRuleConfig{ByteUnitStyle: string(byteUnitStyleIEC)}
+	if err := runner.DecodeRuleConfig((&MSKTopicConfigCommentsRule{}).Name(), &ruleConfig); err != nil {
+		return "", fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	style := byteUnitStyle(ruleConfig.ByteUnitStyle)
+	if style != byteUnitStyleIEC && style != byteUnitStyleSI {
+		return "", fmt.Errorf(
+			"byte_unit_style must be one of [%s %s], got '%s'",
+			byteUnitStyleIEC,
+			byteUnitStyleSI,
+			style,
+		)
+	}
+	return style, nil
+}
+
 // MSKTopicConfigCommentsRule checks comments on time and bytes values.
 type MSKTopicConfigCommentsRule struct {
 	tflint.DefaultRule
@@ -46,8 +157,32 @@ func (r *MSKTopicConfigCommentsRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	unitWords, err := getTimeUnitWords(runner)
+	if err != nil {
+		return err
+	}
+	baseComments, err := getBaseComments(runner)
+	if err != nil {
+		return err
+	}
+	byteUnits, err := getByteUnitStyle(runner)
+	if err != nil {
+		return err
+	}
+	commentInfos := configCommentInfos{
+		time:      withBaseComments(configTimeValueCommentInfos, baseComments),
+		byte:      withBaseComments(configByteValueCommentInfos, baseComments),
+		unitWords: unitWords,
+		byteUnits: byteUnits,
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
 	resourceContents, err := runner.GetResourceContent(
-		"kafka_topic",
+		resourceType,
 		&hclext.BodySchema{
 			Attributes: []hclext.AttributeSchema{
 				{Name: "config"},
@@ -60,7 +195,11 @@ func (r *MSKTopicConfigCommentsRule) Check(runner tflint.Runner) error {
 	}
 
 	for _, topicResource := range resourceContents.Blocks {
-		if err := r.validateTopicConfigComments(runner, topicResource); err != nil {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+		if err := r.validateTopicConfigComments(runner, topicResource, commentInfos); err != nil {
 			return err
 		}
 	}
@@ -68,9 +207,13 @@ func (r *MSKTopicConfigCommentsRule) Check(runner tflint.Runner) error {
 	return nil
 }
 
-func (r *MSKTopicConfigCommentsRule) validateTopicConfigComments(runner tflint.Runner, topic *hclext.Block) error {
+func (r *MSKTopicConfigCommentsRule) validateTopicConfigComments(
+	runner tflint.Runner,
+	topic *hclext.Block,
+	commentInfos configCommentInfos,
+) error {
 	configAttr, hasConfig := topic.Body.Attributes["config"]
-	if !hasConfig {
+	if !hasConfig || skipNonObjectConfig(topic, configAttr) {
 		return nil
 	}
 
@@ -80,12 +223,72 @@ func (r *MSKTopicConfigCommentsRule) validateTopicConfigComments(runner tflint.R
 		return err
 	}
 
-	if err = r.validateConfigValuesInComments(runner, configKeyToPairMap); err != nil {
+	if err = r.validateCommentsSpacing(runner, configAttr); err != nil {
+		return err
+	}
+
+	if err = r.validateConfigValuesInComments(runner, configKeyToPairMap, commentInfos); err != nil {
 		return err
 	}
 	return nil
 }
 
+// validateCommentsSpacing checks that the '#' comments in the config have exactly one space after '#'.
+func (r *MSKTopicConfigCommentsRule) validateCommentsSpacing(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	comments, err := getCommentsForFile(runner, configAttr.Range.Filename)
+	if err != nil {
+		return err
+	}
+
+	configRange := configAttr.Expr.Range()
+	for _, comment := range comments {
+		if comment.Range.Start.Byte < configRange.Start.Byte || comment.Range.End.Byte > configRange.End.Byte {
+			continue
+		}
+
+		rawTxt := string(comment.Bytes)
+		commentTxt := strings.TrimSpace(rawTxt)
+		normalized, ok := normalizeCommentSpacing(commentTxt)
+		if !ok || normalized == commentTxt {
+			continue
+		}
+		if strings.HasSuffix(rawTxt, "\n") {
+			normalized += "\n"
+		}
+
+		err := runner.EmitIssueWithFix(
+			r,
+			"config comments must have exactly one space after '#': fixing it ...",
+			comment.Range,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(comment.Range, normalized)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: comment spacing: %w", err)
+		}
+	}
+	return nil
+}
+
+/*
+normalizeCommentSpacing returns the comment with exactly one space after '#'.
+It returns false for the comments which must be left untouched: other comment styles,
+empty comments, comments starting with several '#' and tflint-ignore directives.
+*/
+func normalizeCommentSpacing(commentTxt string) (string, bool) {
+	body, isHashComment := strings.CutPrefix(commentTxt, "#")
+	if !isHashComment {
+		return "", false
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" || strings.HasPrefix(body, "#") || strings.HasPrefix(body, "tflint-ignore") {
+		return "", false
+	}
+	return "# " + body, true
+}
+
 type configValueCommentInfo struct {
 	key              string
 	infiniteValue    string
@@ -97,7 +300,7 @@ var configTimeValueCommentInfos = []configValueCommentInfo{
 	{
 		key:              retentionTimeAttr,
 		infiniteValue:    "-1",
-		baseComment:      "keep data",
+		baseComment:      retentionTimeCommentBase,
 		issueWhenInvalid: false,
 	},
 	{
@@ -107,11 +310,35 @@ var configTimeValueCommentInfos = []configValueCommentInfo{
 		issueWhenInvalid: false,
 	},
 	{
-		key:              "max.compaction.lag.ms",
+		key:              deleteRetentionTimeAttr,
+		infiniteValue:    "",
+		baseComment:      deleteRetentionTimeCommentBase,
+		issueWhenInvalid: true,
+	},
+	{
+		key:              minCompactionLagAttr,
+		infiniteValue:    "",
+		baseComment:      "keep not compacted keys minimum",
+		issueWhenInvalid: true,
+	},
+	{
+		key:              maxCompactionLagAttr,
 		infiniteValue:    "",
 		baseComment:      "allow not compacted keys maximum",
 		issueWhenInvalid: true,
 	},
+	{
+		key:              "segment.ms",
+		infiniteValue:    "",
+		baseComment:      "keep a segment open maximum",
+		issueWhenInvalid: true,
+	},
+	{
+		key:              "flush.ms",
+		infiniteValue:    "",
+		baseComment:      "delay flushing to disk maximum",
+		issueWhenInvalid: true,
+	},
 }
 
 var configByteValueCommentInfos = []configValueCommentInfo{
@@ -127,19 +354,34 @@ var configByteValueCommentInfos = []configValueCommentInfo{
 		baseComment:      "keep on each partition",
 		issueWhenInvalid: true,
 	},
+	{
+		key:              "segment.bytes",
+		infiniteValue:    "",
+		baseComment:      "roll a new segment at",
+		issueWhenInvalid: true,
+	},
+}
+
+// configCommentInfos are the infos of the commented config values, with the wording configured for a Check.
+type configCommentInfos struct {
+	time      []configValueCommentInfo
+	byte      []configValueCommentInfo
+	unitWords map[string]string
+	byteUnits byteUnitStyle
 }
 
 func (r *MSKTopicConfigCommentsRule) validateConfigValuesInComments(
 	runner tflint.Runner,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
+	commentInfos configCommentInfos,
 ) error {
-	for _, configValueInfo := range configTimeValueCommentInfos {
-		if err := r.validateTimeConfigValue(runner, configKeyToPairMap, configValueInfo); err != nil {
+	for _, configValueInfo := range commentInfos.time {
+		if err := r.validateTimeConfigValue(runner, configKeyToPairMap, configValueInfo, commentInfos.unitWords); err != nil {
 			return err
 		}
 	}
-	for _, configValueInfo := range configByteValueCommentInfos {
-		if err := r.validateByteConfigValue(runner, configKeyToPairMap, configValueInfo); err != nil {
+	for _, configValueInfo := range commentInfos.byte {
+		if err := r.validateByteConfigValue(runner, configKeyToPairMap, configValueInfo, commentInfos.byteUnits); err != nil {
 			return err
 		}
 	}
@@ -151,6 +393,7 @@ func (r *MSKTopicConfigCommentsRule) validateTimeConfigValue(
 	runner tflint.Runner,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
 	configValueInfo configValueCommentInfo,
+	unitWords map[string]string,
 ) error {
 	key := configValueInfo.key
 	timePair, hasConfig := configKeyToPairMap[key]
@@ -158,7 +401,7 @@ func (r *MSKTopicConfigCommentsRule) validateTimeConfigValue(
 		return nil
 	}
 
-	msg, err := r.buildDurationComment(runner, timePair, configValueInfo)
+	msg, err := r.buildDurationComment(runner, timePair, configValueInfo, unitWords)
 	if err != nil {
 		return err
 	}
@@ -166,13 +409,14 @@ func (r *MSKTopicConfigCommentsRule) validateTimeConfigValue(
 		return nil
 	}
 
-	return r.reportHumanReadableComment(runner, timePair, key, msg)
+	return r.reportHumanReadableComment(runner, timePair, key, configValueInfo.baseComment, msg)
 }
 
 func (r *MSKTopicConfigCommentsRule) validateByteConfigValue(
 	runner tflint.Runner,
 	configKeyToPairMap map[string]hcl.KeyValuePair,
 	configValueInfo configValueCommentInfo,
+	byteUnits byteUnitStyle,
 ) error {
 	key := configValueInfo.key
 	dataPair, hasConfig := configKeyToPairMap[key]
@@ -180,7 +424,7 @@ func (r *MSKTopicConfigCommentsRule) validateByteConfigValue(
 		return nil
 	}
 
-	msg, err := r.buildDataSizeComment(runner, dataPair, configValueInfo)
+	msg, err := r.buildDataSizeComment(runner, dataPair, configValueInfo, byteUnits)
 	if err != nil {
 		return err
 	}
@@ -188,16 +432,17 @@ func (r *MSKTopicConfigCommentsRule) validateByteConfigValue(
 		return nil
 	}
 
-	return r.reportHumanReadableComment(runner, dataPair, key, msg)
+	return r.reportHumanReadableComment(runner, dataPair, key, configValueInfo.baseComment, msg)
 }
 
 func (r *MSKTopicConfigCommentsRule) reportHumanReadableComment(
 	runner tflint.Runner,
 	keyValuePair hcl.KeyValuePair,
 	key string,
+	commentBase string,
 	commentMsg string,
 ) error {
-	comment, err := r.getExistingComment(runner, keyValuePair)
+	comment, err := getExistingComment(runner, keyValuePair)
 	if err != nil {
 		return err
 	}
@@ -217,7 +462,17 @@ func (r *MSKTopicConfigCommentsRule) reportHumanReadableComment(
 		return nil
 	}
 
+	if comment.Range.Start.Line == keyValuePair.Value.Range().End.Line {
+		if err := r.reportPreviousLineDuplicateComment(runner, keyValuePair, key, commentBase); err != nil {
+			return err
+		}
+	}
+
 	commentTxt := strings.TrimSpace(string(comment.Bytes))
+	// the spacing after '#' is reported separately
+	if normalized, ok := normalizeCommentSpacing(commentTxt); ok {
+		commentTxt = normalized
+	}
 	if commentTxt != commentMsg {
 		issueMsg := fmt.Sprintf(
 			"%s value doesn't correspond to the human readable value in the comment: fixing it ...",
@@ -235,42 +490,99 @@ func (r *MSKTopicConfigCommentsRule) reportHumanReadableComment(
 	return nil
 }
 
-func (r *MSKTopicConfigCommentsRule) getExistingComment(
+// reportPreviousLineDuplicateComment reports a value comment on the line before a key which also has an inline comment.
+// Only the inline comment is validated, so the one on the previous line is stale and gets removed.
+func (r *MSKTopicConfigCommentsRule) reportPreviousLineDuplicateComment(
+	runner tflint.Runner,
+	keyValuePair hcl.KeyValuePair,
+	key string,
+	commentBase string,
+) error {
+	prevComment, err := getPreviousLineComment(runner, keyValuePair)
+	if err != nil {
+		return err
+	}
+	if prevComment == nil || !isValueComment(prevComment, commentBase) {
+		return nil
+	}
+
+	// remove the whole line, including the indentation before the comment
+	removeRange := prevComment.Range
+	removeRange.Start.Byte -= removeRange.Start.Column - 1
+	removeRange.Start.Column = 1
+
+	err = runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("%s has both a comment on the previous line and an inline comment: removing the one on the previous line ...", key),
+		prevComment.Range,
+		func(f tflint.Fixer) error {
+			return f.Remove(removeRange)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: duplicate comment for human readable value: %w", err)
+	}
+	return nil
+}
+
+// getExistingComment returns the comment of a config key, either on the same line after its value or on the line before it.
+// It is shared with the config rule, which removes the comments of the keys it removes.
+func getExistingComment(
 	runner tflint.Runner,
 	pair hcl.KeyValuePair,
 ) (*hclsyntax.Token, error) {
-	comments, err := r.getCommentsForFile(runner, pair.Key.Range().Filename)
+	comments, err := getCommentsForFile(runner, pair.Key.Range().Filename)
 	if err != nil {
 		return nil, err
 	}
 
 	// first look for the comment on the same line, after the property definition.
 	// Example: "retention.ms" = "2629800000" # keep data for 30 days
+	// The value can be on a line after the key, so the comment is looked for on the line where the value ends.
+	valueEnd := pair.Value.Range().End
 	afterPropertyIdx := slices.IndexFunc(comments, func(comment hclsyntax.Token) bool {
-		return comment.Range.Start.Line == pair.Key.Range().Start.Line &&
-			comment.Range.Start.Column > pair.Value.Range().End.Column
+		return comment.Range.Start.Line == valueEnd.Line &&
+			comment.Range.Start.Column > valueEnd.Column
 	})
 
 	if afterPropertyIdx >= 0 {
 		return &comments[afterPropertyIdx], nil
 	}
 
-	/* second, look for the comment on the previous line, before the property definition. Example:
+	// second, look for the comment on the previous line, before the property definition.
+	return findPreviousLineComment(comments, pair), nil
+}
+
+// getPreviousLineComment returns the comment on the line before a config key, if any.
+func getPreviousLineComment(
+	runner tflint.Runner,
+	pair hcl.KeyValuePair,
+) (*hclsyntax.Token, error) {
+	comments, err := getCommentsForFile(runner, pair.Key.Range().Filename)
+	if err != nil {
+		return nil, err
+	}
+	return findPreviousLineComment(comments, pair), nil
+}
+
+/*
+findPreviousLineComment looks for the comment on the line before the property definition. Example:
+
 	# keep data for 30 days
 	"retention.ms" = "2629800000"
-	*/
+*/
+func findPreviousLineComment(comments hclsyntax.Tokens, pair hcl.KeyValuePair) *hclsyntax.Token {
 	beforePropertyIdx := slices.IndexFunc(comments, func(comment hclsyntax.Token) bool {
 		return comment.Range.Start.Line == pair.Key.Range().Start.Line-1 &&
 			comment.Range.End.Line == pair.Key.Range().Start.Line
 	})
 	if beforePropertyIdx >= 0 {
-		return &comments[beforePropertyIdx], nil
+		return &comments[beforePropertyIdx]
 	}
-
-	return nil, nil
+	return nil
 }
 
-func (r *MSKTopicConfigCommentsRule) getCommentsForFile(
+func getCommentsForFile(
 	runner tflint.Runner,
 	filename string,
 ) (hclsyntax.Tokens, error) {
@@ -296,11 +608,16 @@ func (r *MSKTopicConfigCommentsRule) buildDurationComment(
 	runner tflint.Runner,
 	timePair hcl.KeyValuePair,
 	configValueInfo configValueCommentInfo,
+	unitWords map[string]string,
 ) (string, error) {
-	var timeVal string
-	diags := gohcl.DecodeExpression(timePair.Value, nil, &timeVal)
-	if diags.HasErrors() {
-		return "", diags
+	timeVal, ok, err := decodeConfigValue(timePair)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	if timeVal == configValuePlaceholder {
+		logger.Debug("skipping the placeholder value, which must be replaced by a valid one", "key", configValueInfo.key)
+		return "", nil
 	}
 
 	if timeVal == configValueInfo.infiniteValue {
@@ -323,18 +640,23 @@ func (r *MSKTopicConfigCommentsRule) buildDurationComment(
 		return "", nil
 	}
 
-	return buildCommentForMillis(timeMillis, configValueInfo.baseComment), nil
+	return buildCommentForMillis(timeMillis, configValueInfo.baseComment, unitWords), nil
 }
 
 func (r *MSKTopicConfigCommentsRule) buildDataSizeComment(
 	runner tflint.Runner,
 	dataPair hcl.KeyValuePair,
 	configValueInfo configValueCommentInfo,
+	byteUnits byteUnitStyle,
 ) (string, error) {
-	var dataVal string
-	diags := gohcl.DecodeExpression(dataPair.Value, nil, &dataVal)
-	if diags.HasErrors() {
-		return "", diags
+	dataVal, ok, err := decodeConfigValue(dataPair)
+	if err != nil || !ok {
+		return "", err
+	}
+
+	if dataVal == configValuePlaceholder {
+		logger.Debug("skipping the placeholder value, which must be replaced by a valid one", "key", configValueInfo.key)
+		return "", nil
 	}
 
 	if dataVal == configValueInfo.infiniteValue {
@@ -357,47 +679,68 @@ func (r *MSKTopicConfigCommentsRule) buildDataSizeComment(
 		return "", nil
 	}
 
-	return buildCommentForBytes(byteVal, configValueInfo.baseComment), nil
+	return buildCommentForBytes(byteVal, configValueInfo.baseComment, byteUnits), nil
 }
 
-func buildCommentForBytes(bytes int, baseComment string) string {
-	byteUnits, unit := determineByteUnits(bytes)
+func buildCommentForBytes(bytes int, baseComment string, style byteUnitStyle) string {
+	return fmt.Sprintf("# %s %s", baseComment, formatBytes(bytes, style))
+}
+
+// formatBytes returns the human-readable form of the bytes in the unit style, like '2.9MiB'.
+func formatBytes(bytes int, style byteUnitStyle) string {
+	byteUnits, unit := determineByteUnits(bytes, style)
 
 	byteUnitsStr := strconv.FormatFloat(byteUnits, 'f', -1, 64)
-	return fmt.Sprintf("# %s %s%s", baseComment, byteUnitsStr, unit)
+	return byteUnitsStr + unit
 }
 
 const (
 	bytesInOneKiB = 1024
 	bytesInOneMiB = 1024 * bytesInOneKiB
 	bytesInOneGiB = 1024 * bytesInOneMiB
+
+	bytesInOneKB = 1000
+	bytesInOneMB = 1000 * bytesInOneKB
+	bytesInOneGB = 1000 * bytesInOneMB
 )
 
-func determineByteUnits(bytes int) (float64, string) {
-	floatBytes := float64(bytes)
-	gbs := round(floatBytes / bytesInOneGiB)
-	if gbs >= 1 {
-		return gbs, "GiB"
-	}
+type byteUnit struct {
+	name  string
+	bytes float64
+}
 
-	mbs := round(floatBytes / bytesInOneMiB)
-	if mbs >= 1 {
-		return mbs, "MiB"
-	}
+// byteUnitsByStyle are the units of each style, from the largest to the smallest.
+var byteUnitsByStyle = map[byteUnitStyle][]byteUnit{
+	byteUnitStyleIEC: {{"GiB", bytesInOneGiB}, {"MiB", bytesInOneMiB}, {"KiB", bytesInOneKiB}},
+	byteUnitStyleSI:  {{"GB", bytesInOneGB}, {"MB", bytesInOneMB}, {"KB", bytesInOneKB}},
+}
 
-	kbs := round(floatBytes / bytesInOneKiB)
-	if kbs >= 1 {
-		return kbs, "KiB"
+// determineByteUnits picks the largest unit of the style that is at least 1 after rounding.
+func determineByteUnits(bytes int, style byteUnitStyle) (float64, string) {
+	floatBytes := float64(bytes)
+	for _, unit := range byteUnitsByStyle[style] {
+		if units := round(floatBytes / unit.bytes); units >= 1 {
+			return units, unit.name
+		}
 	}
 	return floatBytes, "B"
 }
 
-func buildCommentForMillis(timeMillis int, baseComment string) string {
+func buildCommentForMillis(timeMillis int, baseComment string, unitWords map[string]string) string {
+	msg := fmt.Sprintf("# %s for %s", baseComment, formatMillis(timeMillis, unitWords))
+	return msg
+}
+
+// formatMillis returns the human-readable form of the time, like '2 days'.
+// The unit is replaced by its word in unitWords, when given.
+func formatMillis(timeMillis int, unitWords map[string]string) string {
 	timeUnits, unit := determineTimeUnits(timeMillis)
+	if unitWords != nil {
+		unit = unitWords[unit]
+	}
 
 	timeUnitsStr := strconv.FormatFloat(timeUnits, 'f', -1, 64)
-	msg := fmt.Sprintf("# %s for %s %s", baseComment, timeUnitsStr, unit)
-	return msg
+	return fmt.Sprintf("%s %s", timeUnitsStr, unit)
 }
 
 /*	round to 1 digit precision  */
@@ -408,10 +751,15 @@ func round(val float64) float64 {
 const (
 	millisInOneHour  = 60 * 60 * 1000
 	millisInOneDay   = 24 * millisInOneHour
+	millisInOneWeek  = 7 * millisInOneDay
 	millisInOneMonth = 30 * millisInOneDay
 	millisInOneYear  = 365 * millisInOneDay
 )
 
+/*
+determineTimeUnits picks the largest unit that is at least 1 after rounding, except weeks, used only for whole weeks.
+As a year is longer than 12 months of 30 days, exactly 12 months (360 days) rounds up to 1 year.
+*/
 func determineTimeUnits(millis int) (float64, string) {
 	floatMillis := float64(millis)
 	timeInYears := round(floatMillis / millisInOneYear)
@@ -430,6 +778,15 @@ func determineTimeUnits(millis int) (float64, string) {
 		return timeInMonths, "months"
 	}
 
+	// only whole weeks are expressed in weeks, as '1.4 weeks' is harder to read than '10 days'
+	if millis >= millisInOneWeek && millis%millisInOneWeek == 0 {
+		timeInWeeks := millis / millisInOneWeek
+		if timeInWeeks == 1 {
+			return 1, "week"
+		}
+		return float64(timeInWeeks), "weeks"
+	}
+
 	timeInDays := round(floatMillis / millisInOneDay)
 	if timeInDays >= 1 {
 		if timeInDays == 1 {
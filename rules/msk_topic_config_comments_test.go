@@ -66,6 +66,83 @@ resource "kafka_topic" "topic_wrong_retention_comment" {
 			},
 		},
 	},
+	{
+		name: "retention time with both a stale previous line comment and an inline comment",
+		input: `
+resource "kafka_topic" "topic_double_retention_comment" {
+  name               = "topic_double_retention_comment"
+  replication_factor = 3
+  config = {
+    # keep data for 2 days
+    "retention.ms" = "86400000" # keep data for 1 week
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_double_retention_comment" {
+  name               = "topic_double_retention_comment"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "86400000" # keep data for 1 day
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms has both a comment on the previous line and an inline comment: removing the one on the previous line ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+			{
+				Message: "retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 33},
+					End:      hcl.Pos{Line: 8, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time with an unrelated previous line comment and an inline comment",
+		input: `
+resource "kafka_topic" "topic_documented_retention" {
+  name               = "topic_documented_retention"
+  replication_factor = 3
+  config = {
+    # agreed with the data team
+    "retention.ms" = "86400000" # keep data for 1 day
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "placeholder values like the one put by the config rule",
+		input: `
+resource "kafka_topic" "topic_retention_placeholder" {
+  name               = "topic_retention_placeholder"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "???"
+    "segment.ms"   = "???"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		// the value is only known when planning, which is noticed by the msk_topic_config rule
+		name: "values referencing locals or variables",
+		input: `
+resource "kafka_topic" "topic_retention_reference" {
+  name               = "topic_retention_reference"
+  replication_factor = 3
+  config = {
+    "retention.ms"    = local.one_day_ms # keep data for 1 day
+    "retention.bytes" = var.retention_bytes
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
 	{
 		name: "retention time good infinite comment",
 		input: `
@@ -116,6 +193,74 @@ resource "kafka_topic" "topic_good_retention_comment_years" {
 }`,
 		expected: []*helper.Issue{},
 	},
+	{
+		name: "retention time of exactly 12 months is expressed in years",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "31104000000" # keep data for 12 months
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "31104000000" # keep data for 1 year
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 36},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time of exactly 1 month",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "2592000000" # keep data for 1 month
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "retention time of exactly 30 days is expressed in months",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "2592000000" # keep data for 30 days
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "2592000000" # keep data for 1 month
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 35},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
 	{
 		name: "retention time in years not precise",
 		input: `
@@ -149,6 +294,126 @@ resource "kafka_topic" "topic_good_retention_comment_years" {
   config = {
     "retention.ms" = "6480000000" # keep data for 2.5 months 
   }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "retention time of exactly 1 week",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "604800000" # keep data for 1 week
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "retention time of 14 days is expressed in weeks",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "1209600000" # keep data for 14 days
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "1209600000" # keep data for 2 weeks
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 35},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time of 8 days is expressed in days",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "691200000" # keep data for 8 days
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "retention time of 10 days is expressed in days",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "864000000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "864000000" # keep data for 10 days
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time just under a month is expressed in weeks",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "2419200000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "2419200000" # keep data for 4 weeks
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time of 3 days is expressed in days",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "259200000" # keep data for 3 days
+  }
 }`,
 		expected: []*helper.Issue{},
 	},
@@ -234,86 +499,391 @@ resource "kafka_topic" "topic_def" {
 		expected: []*helper.Issue{},
 	},
 	{
-		name: "max compaction lag without comment",
+		name: "delete retention time without comment",
 		input: `
 resource "kafka_topic" "topic_def" {
   name               = "topic_def"
   replication_factor = 3
   config = {
-    "max.compaction.lag.ms" = "2592000000"
+    "cleanup.policy"      = "compact"
+    "delete.retention.ms" = "86400000"
   }
 }`, fixed: `
 resource "kafka_topic" "topic_def" {
   name               = "topic_def"
   replication_factor = 3
   config = {
-    "max.compaction.lag.ms" = "2592000000" # allow not compacted keys maximum for 1 month
+    "cleanup.policy"      = "compact"
+    "delete.retention.ms" = "86400000" # keep tombstones for 1 day
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "max.compaction.lag.ms must have a comment with the human readable value: adding it ...",
+				Message: "delete.retention.ms must have a comment with the human readable value: adding it ...",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 5},
-					End:      hcl.Pos{Line: 6, Column: 28},
+					Start:    hcl.Pos{Line: 7, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 26},
 				},
 			},
 		},
 	},
 	{
-		name: "max compaction lag with wrong comment",
+		name: "min compaction lag without comment",
 		input: `
-resource "kafka_topic" "topic_wrong_retention_comment" {
-  name               = "topic_wrong_retention_comment"
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
   replication_factor = 3
   config = {
-    "max.compaction.lag.ms" = "3600000" # allow not compacted keys maximum for 1 day
+    "min.compaction.lag.ms" = "3600000"
   }
 }`, fixed: `
-resource "kafka_topic" "topic_wrong_retention_comment" {
-  name               = "topic_wrong_retention_comment"
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
   replication_factor = 3
   config = {
-    "max.compaction.lag.ms" = "3600000" # allow not compacted keys maximum for 1 hour
+    "min.compaction.lag.ms" = "3600000" # keep not compacted keys minimum for 1 hour
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "max.compaction.lag.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Message: "min.compaction.lag.ms must have a comment with the human readable value: adding it ...",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 41},
-					End:      hcl.Pos{Line: 7, Column: 1},
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 28},
 				},
 			},
 		},
 	},
 	{
-		// the value is validated in the msk_topic_config rule
-		name: "max compaction lag invalid",
+		name: "max compaction lag without comment",
 		input: `
 resource "kafka_topic" "topic_def" {
   name               = "topic_def"
   replication_factor = 3
   config = {
-    "max.compaction.lag.ms" = "invalid-val"
+    "max.compaction.lag.ms" = "2592000000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "max.compaction.lag.ms" = "2592000000" # allow not compacted keys maximum for 1 month
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "max.compaction.lag.ms must have a valid integer value expressed in milliseconds",
+				Message: "max.compaction.lag.ms must have a comment with the human readable value: adding it ...",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 44},
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 28},
 				},
 			},
 		},
 	},
-}
-
-var configByteCommentsTests = []topicConfigTestCase{
+	{
+		name: "max compaction lag with wrong comment",
+		input: `
+resource "kafka_topic" "topic_wrong_retention_comment" {
+  name               = "topic_wrong_retention_comment"
+  replication_factor = 3
+  config = {
+    "max.compaction.lag.ms" = "3600000" # allow not compacted keys maximum for 1 day
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_wrong_retention_comment" {
+  name               = "topic_wrong_retention_comment"
+  replication_factor = 3
+  config = {
+    "max.compaction.lag.ms" = "3600000" # allow not compacted keys maximum for 1 hour
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "max.compaction.lag.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 41},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		// the value is validated in the msk_topic_config rule
+		name: "max compaction lag invalid",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "max.compaction.lag.ms" = "invalid-val"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "max.compaction.lag.ms must have a valid integer value expressed in milliseconds",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 44},
+				},
+			},
+		},
+	},
+	{
+		name: "segment time without a comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "segment.ms" = "604800000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "segment.ms" = "604800000" # keep a segment open maximum for 1 week
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 17},
+				},
+			},
+		},
+	},
+	{
+		name: "segment time with wrong comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    # keep a segment open maximum for 1 day
+    "segment.ms" = "604800000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    # keep a segment open maximum for 1 week
+    "segment.ms" = "604800000"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "segment time invalid",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "segment.ms" = "invalid-val"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.ms must have a valid integer value expressed in milliseconds",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 20},
+					End:      hcl.Pos{Line: 6, Column: 33},
+				},
+			},
+		},
+	},
+	{
+		name: "flush time without a comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "flush.ms" = "3600000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "flush.ms" = "3600000" # delay flushing to disk maximum for 1 hour
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "flush.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 15},
+				},
+			},
+		},
+	},
+	{
+		name: "flush time with wrong comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    # delay flushing to disk maximum for 1 day
+    "flush.ms" = "3600000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    # delay flushing to disk maximum for 1 hour
+    "flush.ms" = "3600000"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "flush.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "flush time invalid",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "flush.ms" = "invalid-val"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "flush.ms must have a valid integer value expressed in milliseconds",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 18},
+					End:      hcl.Pos{Line: 6, Column: 31},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time comments with overridden base comments",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled       = true
+  base_comments = {
+    "retention.ms" = "retain data"
+  }
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "86400000"
+    "segment.ms"   = "3600000" # keep a segment open maximum for 1 hour
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "86400000" # retain data for 1 day
+    "segment.ms"   = "3600000"  # keep a segment open maximum for 1 hour
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time comments with localized unit words",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled    = true
+  unit_words = {
+    hour   = "heure"
+    hours  = "heures"
+    day    = "jour"
+    days   = "jours"
+    week   = "semaine"
+    weeks  = "semaines"
+    month  = "mois"
+    months = "mois"
+    year   = "an"
+    years  = "ans"
+  }
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms"       = "5184000000"
+    "local.retention.ms" = "86400000" # keep data in primary storage for 1 day
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms"       = "5184000000" # keep data for 2 mois
+    "local.retention.ms" = "86400000"   # keep data in primary storage for 1 jour
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 19},
+				},
+			},
+			{
+				Message: "local.retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 39},
+					End:      hcl.Pos{Line: 8, Column: 1},
+				},
+			},
+		},
+	},
+}
+
+var configByteCommentsTests = []topicConfigTestCase{
 	{
 		name: "max message bytes without a comment",
 		input: `
@@ -331,11 +901,104 @@ resource "kafka_topic" "topic_def" {
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "max.message.bytes must have a comment with the human readable value: adding it ...",
+				Message: "max.message.bytes must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 24},
+				},
+			},
+		},
+	},
+	{
+		name: "max message bytes in iec units",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled         = true
+  byte_unit_style = "iec"
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "max.message.bytes" = "3000000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "max.message.bytes" = "3000000" # allow for a batch of records maximum 2.9MiB
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "max.message.bytes must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 24},
+				},
+			},
+		},
+	},
+	{
+		name: "max message bytes in si units",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled         = true
+  byte_unit_style = "si"
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "max.message.bytes" = "3000000"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "max.message.bytes" = "3000000" # allow for a batch of records maximum 3MB
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "max.message.bytes must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 24},
+				},
+			},
+		},
+	},
+	{
+		name: "segment bytes with iec comment in si units",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled         = true
+  byte_unit_style = "si"
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "1073741824" # roll a new segment at 1GiB
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "1073741824" # roll a new segment at 1.1GB
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.bytes value doesn't correspond to the human readable value in the comment: fixing it ...",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 5},
-					End:      hcl.Pos{Line: 5, Column: 24},
+					Start:    hcl.Pos{Line: 5, Column: 36},
+					End:      hcl.Pos{Line: 6, Column: 1},
 				},
 			},
 		},
@@ -465,6 +1128,263 @@ resource "kafka_topic" "topic_def" {
 			},
 		},
 	},
+	{
+		name: "segment bytes without a comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "1073741824"
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "1073741824" # roll a new segment at 1GiB
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.bytes must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 20},
+				},
+			},
+		},
+	},
+	{
+		name: "segment bytes with wrong comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "536870912" # roll a new segment at 1GiB
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "536870912" # roll a new segment at 512MiB
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.bytes value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 35},
+					End:      hcl.Pos{Line: 6, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "segment bytes with correct comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "1073741824" # roll a new segment at 1GiB
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "segment bytes invalid",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "segment.bytes" = "invalid-val"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "segment.bytes must have a valid integer value expressed in bytes",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 23},
+					End:      hcl.Pos{Line: 5, Column: 36},
+				},
+			},
+		},
+	},
+}
+
+var commentSpacingTests = []topicConfigTestCase{
+	{
+		name: "comment without space after hash",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    #keep data for 1 day
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd" #the best compression
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    # keep data for 1 day
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd" # the best compression
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "config comments must have exactly one space after '#': fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+			{
+				Message: "config comments must have exactly one space after '#': fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 8, Column: 33},
+					End:      hcl.Pos{Line: 9, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "comment with several spaces after hash",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "86400000" #   keep data for 1 day
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    "retention.ms" = "86400000" # keep data for 1 day
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "config comments must have exactly one space after '#': fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 33},
+					End:      hcl.Pos{Line: 7, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "tflint ignore directive untouched",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config = {
+    #tflint-ignore: msk_topic_config
+    "compression.type" = "gzip"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+}
+
+var multiLineEntryCommentsTests = []topicConfigTestCase{
+	{
+		name: "multi-line entry with good comment after the value",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = (
+      "86400000"
+    ) # keep data for 1 day
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "multi-line entry with wrong comment after the value",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = (
+      "172800000"
+    ) # keep data for 1 day
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = (
+      "172800000"
+    ) # keep data for 2 days
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms value doesn't correspond to the human readable value in the comment: fixing it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 7},
+					End:      hcl.Pos{Line: 8, Column: 1},
+				},
+			},
+		},
+	},
+	{
+		name: "multi-line entry without comment",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = ( # the default retention
+      "86400000"
+    )
+  }
+}`, fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = ( # the default retention
+      "86400000"
+    ) # keep data for 1 day
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "retention.ms must have a comment with the human readable value: adding it ...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 5},
+					End:      hcl.Pos{Line: 5, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "multi-line entry with good comment before the key",
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    # keep data for 1 day
+    "retention.ms" = (
+      "86400000"
+    )
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
 }
 
 func Test_MSKTopicConfigCommentsRule(t *testing.T) {
@@ -472,21 +1392,201 @@ func Test_MSKTopicConfigCommentsRule(t *testing.T) {
 	var allTests []topicConfigTestCase
 	allTests = append(allTests, configTimeCommentsTests...)
 	allTests = append(allTests, configByteCommentsTests...)
+	allTests = append(allTests, commentSpacingTests...)
+	allTests = append(allTests, multiLineEntryCommentsTests...)
+
+	runTopicConfigTests(t, rule, allTests)
+}
+
+// Test_MSKTopicConfigCommentsRuleIdempotent runs the rule again on the fixed output of each test case,
+// making sure the fixes don't get re-applied on a second run.
+func Test_MSKTopicConfigCommentsRuleIdempotent(t *testing.T) {
+	rule := &MSKTopicConfigCommentsRule{}
+	var allTests []topicConfigTestCase
+	allTests = append(allTests, configTimeCommentsTests...)
+	allTests = append(allTests, configByteCommentsTests...)
+	allTests = append(allTests, commentSpacingTests...)
+	allTests = append(allTests, multiLineEntryCommentsTests...)
 
 	for _, tc := range allTests {
+		if tc.fixed == "" {
+			continue
+		}
+
 		t.Run(tc.name, func(t *testing.T) {
-			runner := helper.TestRunner(t, map[string]string{fileName: tc.input})
+			runner := helper.TestRunner(t, tc.files())
 			require.NoError(t, rule.Check(runner))
 
-			setExpectedRule(tc.expected, rule)
-			t.Logf("Proposed changes: %s", string(runner.Changes()[fileName]))
-			helper.AssertIssues(t, tc.expected, runner.Issues)
+			fixedOnce := string(runner.Changes()[fileName])
+			require.Equal(t, tc.fixed, fixedOnce)
+
+			tc.input = fixedOnce
+			secondRunner := helper.TestRunner(t, tc.files())
+			require.NoError(t, rule.Check(secondRunner))
+
+			t.Logf("Proposed changes on second run: %s", string(secondRunner.Changes()[fileName]))
+			assert.Empty(t, secondRunner.Changes())
+		})
+	}
+}
 
-			if tc.fixed != "" {
-				helper.AssertChanges(t, map[string]string{fileName: tc.fixed}, runner.Changes())
-			} else {
-				assert.Empty(t, runner.Changes())
+func Test_LocalRetentionCommentMatchesConfigRuleFix(t *testing.T) {
+	for _, tc := range []struct {
+		name             string
+		config           string
+		retentionComment string
+		comment          string
+	}{
+		{
+			name:             "default unit words",
+			retentionComment: "# keep data for 1 month",
+			comment:          "# keep data in primary storage for 1 day",
+		},
+		{
+			name: "localized unit words",
+			config: `
+rule "msk_topic_config_comments" {
+  enabled    = true
+  unit_words = {
+    hour   = "heure"
+    hours  = "heures"
+    day    = "jour"
+    days   = "jours"
+    week   = "semaine"
+    weeks  = "semaines"
+    month  = "mois"
+    months = "mois"
+    year   = "an"
+    years  = "ans"
+  }
+}`,
+			retentionComment: "# keep data for 1 mois",
+			comment:          "# keep data in primary storage for 1 jour",
+		},
+		{
+			name: "overridden base comments",
+			config: `
+rule "msk_topic_config_comments" {
+  enabled       = true
+  base_comments = {
+    "local.retention.ms" = "keep data on the brokers"
+  }
+}`,
+			retentionComment: "# keep data for 1 month",
+			comment:          "# keep data on the brokers for 1 day",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			topicTc := topicConfigTestCase{
+				config: tc.config,
+				input: `
+resource "kafka_topic" "topic_with_tiered_storage" {
+  name               = "topic_with_tiered_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000" ` + tc.retentionComment + `
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
 			}
+
+			configRunner := helper.TestRunner(t, topicTc.files())
+			require.NoError(t, (&MSKTopicConfigRule{}).Check(configRunner))
+
+			fixed := string(configRunner.Changes()[fileName])
+			require.Contains(t, fixed, tc.comment)
+
+			topicTc.input = fixed
+			commentsRunner := helper.TestRunner(t, topicTc.files())
+			require.NoError(t, (&MSKTopicConfigCommentsRule{}).Check(commentsRunner))
+
+			helper.AssertIssues(t, helper.Issues{}, commentsRunner.Issues)
+			assert.Empty(t, commentsRunner.Changes())
 		})
 	}
 }
+
+func Test_MSKTopicConfigCommentsRuleIncompleteUnitWords(t *testing.T) {
+	tc := topicConfigTestCase{
+		config: `
+rule "msk_topic_config_comments" {
+  enabled    = true
+  unit_words = {
+    day  = "jour"
+    days = "jours"
+  }
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+	}
+
+	runner := helper.TestRunner(t, tc.files())
+	require.EqualError(
+		t,
+		(&MSKTopicConfigCommentsRule{}).Check(runner),
+		"unit_words must define the words of all the units [hour hours day days week weeks month months year years], but misses [hour hours week weeks month months year years]",
+	)
+}
+
+func Test_MSKTopicConfigCommentsRuleModuleOutputConfig(t *testing.T) {
+	rule := &MSKTopicConfigCommentsRule{}
+
+	runner := helper.TestRunner(t, map[string]string{fileName: `
+resource "kafka_topic" "topic_def" {
+  name   = "topic_def"
+  config = module.defaults.topic_config
+}`})
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{}, runner.Issues)
+	assert.Empty(t, runner.Changes())
+}
+
+func Test_MSKTopicConfigCommentsRuleUnknownBaseComments(t *testing.T) {
+	tc := topicConfigTestCase{
+		config: `
+rule "msk_topic_config_comments" {
+  enabled       = true
+  base_comments = {
+    "cleanup.policy" = "clean up with"
+  }
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+	}
+
+	runner := helper.TestRunner(t, tc.files())
+	require.EqualError(
+		t,
+		(&MSKTopicConfigCommentsRule{}).Check(runner),
+		"base_comments can only override the comments of the keys [retention.ms local.retention.ms delete.retention.ms min.compaction.lag.ms max.compaction.lag.ms segment.ms flush.ms max.message.bytes retention.bytes segment.bytes], but defines 'cleanup.policy'",
+	)
+}
+
+func Test_MSKTopicConfigCommentsRuleInvalidByteUnitStyle(t *testing.T) {
+	tc := topicConfigTestCase{
+		config: `
+rule "msk_topic_config_comments" {
+  enabled         = true
+  byte_unit_style = "jedec"
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+	}
+
+	runner := helper.TestRunner(t, tc.files())
+	require.EqualError(
+		t,
+		(&MSKTopicConfigCommentsRule{}).Check(runner),
+		"byte_unit_style must be one of [iec si], got 'jedec'",
+	)
+}
@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicConfigCompressionFirstRule checks that the compression type is defined before the cleanup policy
+// in the topic config, as required by the style guide of some teams.
+type MSKTopicConfigCompressionFirstRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) Name() string {
+	return "msk_topic_config_compression_first"
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+		if err := r.validateCompressionFirst(runner, configAttr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicConfigCompressionFirstRule) validateCompressionFirst(
+	runner tflint.Runner,
+	configAttr *hclext.Attribute,
+) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	compressionIdx, cleanupIdx := -1, -1
+	for idx, item := range configExpr.Items {
+		var key string
+		diags := gohcl.DecodeExpression(item.KeyExpr, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+		switch key {
+		case compressionTypeKey:
+			compressionIdx = idx
+		case cleanupPolicyKey:
+			cleanupIdx = idx
+		}
+	}
+	if compressionIdx < 0 || cleanupIdx < 0 || compressionIdx < cleanupIdx {
+		return nil
+	}
+
+	// the lines can be swapped only when each of the two entries is on its own lines
+	if !hasOwnLines(configExpr, cleanupIdx) || !hasOwnLines(configExpr, compressionIdx) {
+		logger.Debug("skipping config with several entries on the same line", "range", configAttr.Range)
+		return nil
+	}
+
+	file, err := runner.GetFile(configAttr.Range.Filename)
+	if err != nil {
+		return fmt.Errorf("getting hcl file %s for swapping config keys: %w", configAttr.Range.Filename, err)
+	}
+	lineStarts := findLineStarts(file.Bytes)
+	cleanupLines := itemLinesRange(configExpr.Items[cleanupIdx], lineStarts)
+	compressionLines := itemLinesRange(configExpr.Items[compressionIdx], lineStarts)
+
+	err = runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("'%s' must be defined before '%s': swapping them ...", compressionTypeKey, cleanupPolicyKey),
+		configAttr.Range,
+		func(f tflint.Fixer) error {
+			cleanupText := string(file.Bytes[cleanupLines.Start.Byte:cleanupLines.End.Byte])
+			compressionText := string(file.Bytes[compressionLines.Start.Byte:compressionLines.End.Byte])
+			if err := f.ReplaceText(cleanupLines, compressionText); err != nil {
+				return err //nolint:wrapcheck
+			}
+			return f.ReplaceText(compressionLines, cleanupText) //nolint:wrapcheck
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: compression type after cleanup policy: %w", err)
+	}
+	return nil
+}
+
+// hasOwnLines returns whether the config item at the index doesn't share its lines with other items or the braces.
+func hasOwnLines(configExpr *hclsyntax.ObjectConsExpr, idx int) bool {
+	item := configExpr.Items[idx]
+	prevEndLine := configExpr.OpenRange.Start.Line
+	if idx > 0 {
+		prevEndLine = configExpr.Items[idx-1].ValueExpr.Range().End.Line
+	}
+	nextStartLine := configExpr.SrcRange.End.Line
+	if idx < len(configExpr.Items)-1 {
+		nextStartLine = configExpr.Items[idx+1].KeyExpr.Range().Start.Line
+	}
+	return item.KeyExpr.Range().Start.Line > prevEndLine && item.ValueExpr.Range().End.Line < nextStartLine
+}
+
+// itemLinesRange returns the range of the whole lines of the config item, including the trailing newline.
+func itemLinesRange(item hclsyntax.ObjectConsItem, lineStarts []int) hcl.Range {
+	startLine := item.KeyExpr.Range().Start.Line
+	endLine := item.ValueExpr.Range().End.Line
+	return hcl.Range{
+		Filename: item.KeyExpr.Range().Filename,
+		Start:    hcl.Pos{Line: startLine, Column: 1, Byte: lineStarts[startLine-1]},
+		End:      hcl.Pos{Line: endLine + 1, Column: 1, Byte: lineStarts[endLine]},
+	}
+}
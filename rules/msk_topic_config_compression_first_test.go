@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicConfigCompressionFirstRule(t *testing.T) {
+	rule := &MSKTopicConfigCompressionFirstRule{}
+
+	const swapMsg = "'compression.type' must be defined before 'cleanup.policy': swapping them ..."
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "adjacent keys swapped",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "cleanup.policy"   = "delete"
+    "compression.type" = "zstd"
+    "retention.ms"     = "86400000"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "compression.type" = "zstd"
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: swapMsg,
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 8, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "keys swapped with their inline comments, others untouched",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "cleanup.policy"   = "delete" # the default policy
+    # keep data for 1 day
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd" # set by the producers too
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "compression.type" = "zstd" # set by the producers too
+    # keep data for 1 day
+    "retention.ms"   = "86400000"
+    "cleanup.policy" = "delete" # the default policy
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: swapMsg,
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "keys in order",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "compression.type" = "zstd"
+    "cleanup.policy"   = "delete"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "cleanup policy without compression type",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "cleanup.policy" = "delete"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "keys on the same line are not swapped",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name   = "topic-def"
+  config = { "cleanup.policy" = "delete", "compression.type" = "zstd" }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
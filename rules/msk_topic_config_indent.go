@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// indentWidth is the width of a tab, as indented by 'terraform fmt'.
+const indentWidth = 2
+
+type indentStyle int
+
+const (
+	indentNone indentStyle = iota
+	indentSpaces
+	indentTabs
+	indentMixed
+)
+
+func (s indentStyle) String() string {
+	switch s {
+	case indentSpaces:
+		return "spaces"
+	case indentTabs:
+		return "tabs"
+	case indentMixed:
+		return "mixed tabs and spaces"
+	default:
+		return "none"
+	}
+}
+
+// MSKTopicConfigIndentRule checks that the lines of a topic config don't mix tabs and spaces in their indentation.
+type MSKTopicConfigIndentRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicConfigIndentRule) Name() string {
+	return "msk_topic_config_indent"
+}
+
+func (r *MSKTopicConfigIndentRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicConfigIndentRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicConfigIndentRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *MSKTopicConfigIndentRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+		if err := r.validateConfigIndent(runner, configAttr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type indentedLine struct {
+	rng    hcl.Range
+	indent string
+	style  indentStyle
+}
+
+func (r *MSKTopicConfigIndentRule) validateConfigIndent(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	file, err := runner.GetFile(configAttr.Range.Filename)
+	if err != nil {
+		return fmt.Errorf("getting hcl file %s for checking the config indentation: %w", configAttr.Range.Filename, err)
+	}
+	lineStarts := findLineStarts(file.Bytes)
+
+	// the lines after the opening brace, up to the closing brace
+	var lines []indentedLine
+	styleCounts := map[indentStyle]int{}
+	for line := configExpr.OpenRange.Start.Line + 1; line <= configExpr.SrcRange.End.Line; line++ {
+		start := lineStarts[line-1]
+		content := string(file.Bytes[start:lineStarts[line]])
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		indent := content[:len(content)-len(strings.TrimLeft(content, " \t"))]
+		style := detectIndentStyle(indent)
+		styleCounts[style]++
+		lines = append(lines, indentedLine{
+			rng: hcl.Range{
+				Filename: configAttr.Range.Filename,
+				Start:    hcl.Pos{Line: line, Column: 1, Byte: start},
+				End:      hcl.Pos{Line: line, Column: len(indent) + 1, Byte: start + len(indent)},
+			},
+			indent: indent,
+			style:  style,
+		})
+	}
+
+	// the config is expected to be indented like most of its lines, with spaces on ties like 'terraform fmt'
+	expectedStyle := indentSpaces
+	if styleCounts[indentTabs] > styleCounts[indentSpaces] {
+		expectedStyle = indentTabs
+	}
+
+	for _, line := range lines {
+		if line.style == indentNone || line.style == expectedStyle {
+			continue
+		}
+
+		normalized := normalizeIndent(line.indent, expectedStyle)
+		err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf(
+				"config line indented with %s while the config is indented with %s: normalizing it...",
+				line.style,
+				expectedStyle,
+			),
+			line.rng,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(line.rng, normalized)
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: inconsistent config indentation: %w", err)
+		}
+	}
+	return nil
+}
+
+func detectIndentStyle(indent string) indentStyle {
+	hasTabs := strings.Contains(indent, "\t")
+	hasSpaces := strings.Contains(indent, " ")
+	switch {
+	case hasTabs && hasSpaces:
+		return indentMixed
+	case hasTabs:
+		return indentTabs
+	case hasSpaces:
+		return indentSpaces
+	default:
+		return indentNone
+	}
+}
+
+// normalizeIndent converts the indentation to the style, keeping its width.
+func normalizeIndent(indent string, style indentStyle) string {
+	width := 0
+	for _, c := range indent {
+		if c == '\t' {
+			width += indentWidth
+		} else {
+			width++
+		}
+	}
+	if style == indentTabs {
+		return strings.Repeat("\t", (width+indentWidth-1)/indentWidth)
+	}
+	return strings.Repeat(" ", width)
+}
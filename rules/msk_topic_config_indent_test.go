@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicConfigIndentRule(t *testing.T) {
+	rule := &MSKTopicConfigIndentRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "tab indented line in a space indented config",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy"   = "delete"
+	"retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "config line indented with tabs while the config is indented with spaces: normalizing it...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 2},
+					},
+				},
+			},
+		},
+		{
+			name: "mixed indented line in a space indented config",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy"   = "delete"
+  	"retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "config line indented with mixed tabs and spaces while the config is indented with spaces: normalizing it...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "consistently indented config",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy"   = "delete"
+
+    # keep data for 1 day
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "consistently tab indented config",
+			input: `
+resource "kafka_topic" "topic_def" {
+	name = "topic_def"
+	config = {
+		"cleanup.policy"   = "delete"
+		"retention.ms"     = "86400000"
+		"compression.type" = "zstd"
+	}
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
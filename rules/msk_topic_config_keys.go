@@ -0,0 +1,208 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// knownTopicConfigKeys are the kafka topic config keys honoured by our platform.
+// See [https://kafka.apache.org/documentation/#topicconfigs].
+var knownTopicConfigKeys = []string{
+	cleanupPolicyKey,
+	compressionTypeKey,
+	"delete.retention.ms",
+	"file.delete.delay.ms",
+	"flush.messages",
+	"flush.ms",
+	"index.interval.bytes",
+	"local.retention.bytes",
+	localRetentionTimeAttr,
+	"max.compaction.lag.ms",
+	"max.message.bytes",
+	"message.timestamp.after.max.ms",
+	"message.timestamp.before.max.ms",
+	"message.timestamp.difference.max.ms",
+	timestampTypeKey,
+	"min.cleanable.dirty.ratio",
+	"min.compaction.lag.ms",
+	minInSyncReplicasKey,
+	"preallocate",
+	tieredStorageEnableAttr,
+	"retention.bytes",
+	retentionTimeAttr,
+	"segment.bytes",
+	"segment.index.bytes",
+	"segment.jitter.ms",
+	"segment.ms",
+	"unclean.leader.election.enable",
+}
+
+// legacyTopicConfigKeys maps the tiered storage keys used by older configs to the current key.
+var legacyTopicConfigKeys = map[string]string{
+	// Confluent Platform tiered storage, not honoured by MSK
+	"confluent.tier.enable": tieredStorageEnableAttr,
+	// broker level setting, mistakenly copied in topic configs
+	"remote.log.storage.system.enable": tieredStorageEnableAttr,
+}
+
+type mskTopicConfigKeysRuleConfig struct {
+	AdditionalKeys []string `hclext:"additional_keys,optional"`
+}
+
+// MSKTopicConfigKeysRule warns on topic config keys which are not known, usually typos,
+// and renames the legacy tiered storage keys.
+type MSKTopicConfigKeysRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicConfigKeysRule) Name() string {
+	return "msk_topic_config_keys"
+}
+
+func (r *MSKTopicConfigKeysRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicConfigKeysRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicConfigKeysRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicConfigKeysRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicConfigKeysRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	logger.Debug("decoded rule config: %v", ruleConfig)
+
+	allowedKeys := slices.Concat(knownTopicConfigKeys, ruleConfig.AdditionalKeys)
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+		if err := r.validateConfigKeys(runner, configAttr, allowedKeys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicConfigKeysRule) validateConfigKeys(
+	runner tflint.Runner,
+	configAttr *hclext.Attribute,
+	allowedKeys []string,
+) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	pairs := configExpr.ExprMap()
+	keys := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		var key string
+		diags := gohcl.DecodeExpression(pair.Key, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+		// the whitespace around the keys is reported by the msk_topic_config rule
+		keys = append(keys, strings.TrimSpace(key))
+	}
+
+	for i, pair := range pairs {
+		key := keys[i]
+		if slices.Contains(allowedKeys, key) {
+			continue
+		}
+
+		if currentKey, isLegacy := legacyTopicConfigKeys[key]; isLegacy {
+			if err := r.reportLegacyKey(runner, pair, key, currentKey, slices.Contains(keys, currentKey)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"unknown topic config key '%s': check it for typos or add it to the rule's additional_keys",
+			key,
+		)
+		if err := runner.EmitIssue(r, msg, pair.Key.Range()); err != nil {
+			return fmt.Errorf("emitting issue: unknown config key: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicConfigKeysRule) reportLegacyKey(
+	runner tflint.Runner,
+	pair hcl.KeyValuePair,
+	legacyKey string,
+	currentKey string,
+	hasCurrentKey bool,
+) error {
+	keyRange := pair.Key.Range()
+
+	// renaming would define the current key twice
+	if hasCurrentKey {
+		msg := fmt.Sprintf("legacy tiered storage key '%s' is not honoured and '%s' is already defined: remove it", legacyKey, currentKey)
+		if err := runner.EmitIssue(r, msg, keyRange); err != nil {
+			return fmt.Errorf("emitting issue: legacy config key: %w", err)
+		}
+		return nil
+	}
+
+	msg := fmt.Sprintf("legacy tiered storage key '%s' is not honoured: renaming it to '%s'", legacyKey, currentKey)
+	err := runner.EmitIssueWithFix(r, msg, keyRange,
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(keyRange, fmt.Sprintf("%q", currentKey))
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: legacy config key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicConfigKeysRule(t *testing.T) {
+	rule := &MSKTopicConfigKeysRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "unknown config key",
+			input: `
+resource "kafka_topic" "topic_with_typo" {
+  name = "topic_with_typo"
+  config = {
+    "cleanup.policy" = "delete"
+    "retiontion.ms"  = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "unknown topic config key 'retiontion.ms': check it for typos or add it to the rule's additional_keys",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			name: "known config key with trailing whitespace",
+			input: `
+resource "kafka_topic" "topic_with_padded_key" {
+  name = "topic_with_padded_key"
+  config = {
+    "cleanup.policy" = "delete"
+    "retention.ms "  = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "known config keys",
+			input: `
+resource "kafka_topic" "good_topic" {
+  name = "good_topic"
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+    "segment.bytes"       = "1073741824"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "config key allowed through the rule config",
+			config: `
+rule "msk_topic_config_keys" {
+  enabled         = true
+  additional_keys = ["confluent.value.schema.validation"]
+}`,
+			input: `
+resource "kafka_topic" "topic_with_extra_key" {
+  name = "topic_with_extra_key"
+  config = {
+    "cleanup.policy"                    = "delete"
+    "confluent.value.schema.validation" = "true"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "legacy tiered storage key",
+			input: `
+resource "kafka_topic" "topic_with_legacy_key" {
+  name = "topic_with_legacy_key"
+  config = {
+    "cleanup.policy"        = "delete"
+    "confluent.tier.enable" = "true"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_with_legacy_key" {
+  name = "topic_with_legacy_key"
+  config = {
+    "cleanup.policy"        = "delete"
+    "remote.storage.enable" = "true"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "legacy tiered storage key 'confluent.tier.enable' is not honoured: renaming it to 'remote.storage.enable'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			name: "legacy tiered storage key with the current key defined",
+			input: `
+resource "kafka_topic" "topic_with_legacy_key" {
+  name = "topic_with_legacy_key"
+  config = {
+    "remote.log.storage.system.enable" = "true"
+    "remote.storage.enable"            = "true"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "legacy tiered storage key 'remote.log.storage.system.enable' is not honoured and 'remote.storage.enable' is already defined: remove it",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 39},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicConfigOrderRule checks whether the topic config keys are in the canonical order, to ease the reviews.
+type MSKTopicConfigOrderRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicConfigOrderRule) Name() string {
+	return "msk_topic_config_order"
+}
+
+func (r *MSKTopicConfigOrderRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicConfigOrderRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicConfigOrderRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+/*
+configKeyOrderGroups defines the canonical order of the config keys:
+tiered storage keys, compression type, cleanup policy, retention keys, then everything else alphabetically.
+The compression type comes before the cleanup policy, as required by the msk_topic_config_compression_first rule.
+*/
+var configKeyOrderGroups = [][]string{
+	{tieredStorageEnableAttr, localRetentionTimeAttr, "local.retention.bytes"},
+	{compressionTypeKey},
+	{cleanupPolicyKey},
+	{retentionTimeAttr, "retention.bytes"},
+}
+
+func (r *MSKTopicConfigOrderRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+		if err := r.validateConfigOrder(runner, configAttr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// configEntry holds the source lines of a config key, including the comments and blank lines preceding it.
+type configEntry struct {
+	key   string
+	lines string
+}
+
+func (r *MSKTopicConfigOrderRule) validateConfigOrder(runner tflint.Runner, configAttr *hclext.Attribute) error {
+	configExpr, ok := configAttr.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return fmt.Errorf("could not convert 'config' of type %T to hclsyntax.ObjectConsExpr", configAttr.Expr)
+	}
+
+	file, err := runner.GetFile(configAttr.Range.Filename)
+	if err != nil {
+		return fmt.Errorf("getting hcl file %s for reordering config: %w", configAttr.Range.Filename, err)
+	}
+	lineStarts := findLineStarts(file.Bytes)
+
+	openLine := configExpr.OpenRange.Start.Line
+	closeLine := configExpr.SrcRange.End.Line
+
+	entries := make([]configEntry, 0, len(configExpr.Items))
+	prevEndLine := openLine
+	for _, item := range configExpr.Items {
+		var key string
+		diags := gohcl.DecodeExpression(item.KeyExpr, nil, &key)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		// the entries can be moved only when each of them is on its own lines
+		if item.KeyExpr.Range().Start.Line <= prevEndLine || item.ValueExpr.Range().End.Line >= closeLine {
+			logger.Debug("skipping config with several entries on the same line", "range", configAttr.Range)
+			return nil
+		}
+
+		endLine := item.ValueExpr.Range().End.Line
+		entries = append(entries, configEntry{
+			key:   key,
+			lines: string(file.Bytes[lineStarts[prevEndLine]:lineStarts[endLine]]),
+		})
+		prevEndLine = endLine
+	}
+
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, compareConfigEntries)
+	if slices.Equal(entries, sorted) {
+		return nil
+	}
+
+	var newBody strings.Builder
+	for _, entry := range sorted {
+		newBody.WriteString(entry.lines)
+	}
+
+	// the lines after the last entry, like trailing comments, stay at the end.
+	bodyRange := hcl.Range{
+		Filename: configAttr.Range.Filename,
+		Start:    hcl.Pos{Line: openLine + 1, Column: 1, Byte: lineStarts[openLine]},
+		End:      hcl.Pos{Line: prevEndLine + 1, Column: 1, Byte: lineStarts[prevEndLine]},
+	}
+
+	err = runner.EmitIssueWithFix(
+		r,
+		"config keys must be in the canonical order: tiered storage, compression type, cleanup policy, retention, then the others alphabetically: reordering them ...",
+		configAttr.Range,
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(bodyRange, newBody.String())
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: config keys not in canonical order: %w", err)
+	}
+	return nil
+}
+
+func compareConfigEntries(a, b configEntry) int {
+	aGroup, aIdx := configKeyRank(a.key)
+	bGroup, bIdx := configKeyRank(b.key)
+	if aGroup != bGroup {
+		return aGroup - bGroup
+	}
+	if aIdx != bIdx {
+		return aIdx - bIdx
+	}
+	return strings.Compare(a.key, b.key)
+}
+
+// configKeyRank returns the group of the key and its index in the group.
+// The keys not in any group are placed last and ordered alphabetically.
+func configKeyRank(key string) (int, int) {
+	for group, keys := range configKeyOrderGroups {
+		if idx := slices.Index(keys, key); idx >= 0 {
+			return group, idx
+		}
+	}
+	return len(configKeyOrderGroups), 0
+}
+
+// findLineStarts returns the byte offsets where each line starts, indexed by the 0-based line number.
+// A line number 'n' in a hcl.Pos ends where the line at index 'n' starts.
+func findLineStarts(src []byte) []int {
+	starts := []int{0}
+	offset := 0
+	for {
+		idx := bytes.IndexByte(src[offset:], '\n')
+		if idx < 0 {
+			break
+		}
+		offset += idx + 1
+		starts = append(starts, offset)
+	}
+	return append(starts, len(src))
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicConfigOrderRule(t *testing.T) {
+	rule := &MSKTopicConfigOrderRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "config keys reordered",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "min.insync.replicas"   = "2"
+    "compression.type"      = "zstd"
+    "retention.ms"          = "2592000000"
+    "cleanup.policy"        = "delete"
+    "remote.storage.enable" = "true"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "remote.storage.enable" = "true"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "config keys must be in the canonical order: tiered storage, compression type, cleanup policy, retention, then the others alphabetically: reordering them ...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 10, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "comments moved with their keys",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "compression.type"      = "zstd"
+    "retention.ms"          = "2592000000" # keep data for 1 month
+    "cleanup.policy"        = "delete"
+    # keep data in primary storage for 1 day
+    "local.retention.ms"    = "86400000"
+    "remote.storage.enable" = "true"
+    # trailing comment
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "remote.storage.enable" = "true"
+    # keep data in primary storage for 1 day
+    "local.retention.ms" = "86400000"
+    "compression.type"   = "zstd"
+    "cleanup.policy"     = "delete"
+    "retention.ms"       = "2592000000" # keep data for 1 month
+    # trailing comment
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "config keys must be in the canonical order: tiered storage, compression type, cleanup policy, retention, then the others alphabetically: reordering them ...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 12, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "config keys in canonical order",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000" # keep data for 1 month
+    "min.insync.replicas"   = "2"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "config on a single line",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name   = "topic-def"
+  config = { "compression.type" = "zstd", "cleanup.policy" = "delete" }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
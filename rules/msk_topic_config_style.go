@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicConfigStyleRule checks that the keys of a topic config are double-quoted strings, like the kafka config keys.
+type MSKTopicConfigStyleRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicConfigStyleRule) Name() string {
+	return "msk_topic_config_style"
+}
+
+func (r *MSKTopicConfigStyleRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicConfigStyleRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicConfigStyleRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *MSKTopicConfigStyleRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		if err := r.validateConfigKeysQuoted(runner, configKeyToPairMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicConfigStyleRule) validateConfigKeysQuoted(
+	runner tflint.Runner,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+) error {
+	// report the keys in the order they are defined
+	keys := slices.SortedFunc(maps.Keys(configKeyToPairMap), func(a, b string) int {
+		return cmp.Compare(configKeyToPairMap[a].Key.Range().Start.Byte, configKeyToPairMap[b].Key.Range().Start.Byte)
+	})
+
+	for _, key := range keys {
+		keyExpr := configKeyToPairMap[key].Key
+		if isQuotedKey(keyExpr) {
+			continue
+		}
+
+		keyRange := keyExpr.Range()
+		err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("config key '%s' must be a double-quoted string, like the kafka config keys: quoting it...", key),
+			keyRange,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(keyRange, strconv.Quote(key))
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: unquoted config key: %w", err)
+		}
+	}
+	return nil
+}
+
+// isQuotedKey returns whether the key of an object is a double-quoted string literal, not a bare identifier.
+func isQuotedKey(keyExpr hcl.Expression) bool {
+	if objKey, ok := keyExpr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		keyExpr = objKey.Wrapped
+	}
+	tmpl, ok := keyExpr.(*hclsyntax.TemplateExpr)
+	return ok && tmpl.IsStringLiteral()
+}
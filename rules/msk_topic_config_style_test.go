@@ -0,0 +1,64 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicConfigStyleRule(t *testing.T) {
+	rule := &MSKTopicConfigStyleRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "unquoted config key",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "delete"
+    retention_ms     = "86400000"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "delete"
+    "retention_ms"   = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "config key 'retention_ms' must be a double-quoted string, like the kafka config keys: quoting it...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 6, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			name: "quoted config keys",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "topic without config",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
@@ -12,27 +13,60 @@ import (
 
 type topicConfigTestCase struct {
 	name     string
+	config   string
 	input    string
 	fixed    string
 	expected helper.Issues
 }
 
+// files returns the files for the test case, including the tflint config when defined.
+func (tc topicConfigTestCase) files() map[string]string {
+	files := map[string]string{fileName: tc.input}
+	if tc.config != "" {
+		files[".tflint.hcl"] = tc.config
+	}
+	return files
+}
+
 const fileName = "topics.tf"
 
+// runTopicConfigTests runs the rule on the input of each test case, asserting the expected issues and the fixed input.
+func runTopicConfigTests(t *testing.T, rule tflint.Rule, tcs []topicConfigTestCase) {
+	t.Helper()
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files())
+			require.NoError(t, rule.Check(runner))
+
+			setExpectedRule(tc.expected, rule)
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+
+			if tc.fixed != "" {
+				t.Logf("Proposed changes: %s", string(runner.Changes()[fileName]))
+				helper.AssertChanges(t, map[string]string{fileName: tc.fixed}, runner.Changes())
+			} else {
+				assert.Empty(t, runner.Changes())
+			}
+		})
+	}
+}
+
 var replicationFactorTests = []topicConfigTestCase{
 	{
 		name: "missing replication factor and topic name not defined",
 		input: `
 resource "kafka_topic" "topic_without_repl_factor_and_name" {
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing replication_factor: it must be equal to '3'",
+				Message: "[durability] missing replication_factor: it must be equal to '3'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 2, Column: 1},
@@ -48,9 +82,10 @@ resource "kafka_topic" "topic_without_repl_factor_and_name" {
 resource "kafka_topic" "topic_without_repl_factor" {
   name = "topic_without_repl_factor"
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
@@ -58,14 +93,15 @@ resource "kafka_topic" "topic_without_repl_factor" {
   name               = "topic_without_repl_factor"
   replication_factor = 3
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing replication_factor: it must be equal to '3'",
+				Message: "[durability] missing replication_factor: it must be equal to '3'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 2, Column: 1},
@@ -81,9 +117,10 @@ resource "kafka_topic" "topic_with_incorrect_repl_factor" {
   name               = "topic_with_incorrect_repl_factor"
   replication_factor = 10
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
@@ -91,14 +128,15 @@ resource "kafka_topic" "topic_with_incorrect_repl_factor" {
   name               = "topic_with_incorrect_repl_factor"
   replication_factor = 3
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "the replication_factor must be equal to '3'",
+				Message: "[durability] the replication_factor must be equal to '3'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 4, Column: 3},
@@ -119,7 +157,7 @@ resource "kafka_topic" "topic_without_config" {
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing config attribute: the topic configuration must be specified in a config attribute",
+				Message: "[correctness] missing config attribute: the topic configuration must be specified in a config attribute",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 2, Column: 1},
@@ -135,8 +173,9 @@ resource "kafka_topic" "topic_without_compression_type" {
   name               = "topic_without_compression_type"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
@@ -144,18 +183,19 @@ resource "kafka_topic" "topic_without_compression_type" {
   name               = "topic_without_compression_type"
   replication_factor = 3
   config = {
-    "compression.type" = "zstd"
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing compression.type: it must be equal to 'zstd'",
+				Message: "[cost] missing compression.type: it must be equal to 'zstd'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 8, Column: 4},
+					End:      hcl.Pos{Line: 9, Column: 4},
 				},
 			},
 		},
@@ -167,9 +207,10 @@ resource "kafka_topic" "topic_with_wrong_compression_type" {
   name               = "topic_with_wrong_compression_type"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "compression.type" = "gzip"
-    "retention.ms"     = "86400000"
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "gzip"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
@@ -177,659 +218,1890 @@ resource "kafka_topic" "topic_with_wrong_compression_type" {
   name               = "topic_with_wrong_compression_type"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "compression.type" = "zstd"
-    "retention.ms"     = "86400000"
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "the compression.type value must be equal to 'zstd'",
+				Message: "[cost] the compression.type value must be equal to 'zstd'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 7, Column: 26},
-					End:      hcl.Pos{Line: 7, Column: 32},
+					Start:    hcl.Pos{Line: 7, Column: 29},
+					End:      hcl.Pos{Line: 7, Column: 35},
 				},
 			},
 		},
 	},
 }
 
-var cleanupPolicyTests = []topicConfigTestCase{
+var minInSyncReplicasTests = []topicConfigTestCase{
 	{
-		name: "missing cleanup policy",
+		name: "missing min insync replicas",
 		input: `
-resource "kafka_topic" "topic_without_cleanup_policy" {
-  name               = "topic_without_cleanup_policy"
+resource "kafka_topic" "topic_without_min_insync_replicas" {
+  name               = "topic_without_min_insync_replicas"
   replication_factor = 3
   config = {
     "compression.type" = "zstd"
+    "cleanup.policy"   = "delete"
     "retention.ms"     = "86400000"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_without_cleanup_policy" {
-  name               = "topic_without_cleanup_policy"
+resource "kafka_topic" "topic_without_min_insync_replicas" {
+  name               = "topic_without_min_insync_replicas"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "compression.type" = "zstd"
-    "retention.ms"     = "86400000"
+    "min.insync.replicas" = "2"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing cleanup.policy: using default 'delete'",
+				Message: "[durability] missing min.insync.replicas: it must be equal to '2'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 8, Column: 4},
+					End:      hcl.Pos{Line: 9, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "invalid cleanup policy value",
+		name: "missing min insync replicas with a configured replication factor of 1",
+		config: `
+rule "msk_topic_config" {
+  enabled            = true
+  replication_factor = 1
+}`,
 		input: `
-resource "kafka_topic" "topic_with_invalid_cleanup_policy" {
-  name               = "topic_with_invalid_cleanup_policy"
-  replication_factor = 3
+resource "kafka_topic" "topic_with_single_replica" {
+  name               = "topic_with_single_replica"
+  replication_factor = 1
   config = {
-    "cleanup.policy"   = "invalid-value"
     "compression.type" = "zstd"
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "86400000"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_single_replica" {
+  name               = "topic_with_single_replica"
+  replication_factor = 1
+  config = {
+    "min.insync.replicas" = "1"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "invalid cleanup.policy: it must be one of [delete, compact], but currently is 'invalid-value'",
+				Message: "[durability] missing min.insync.replicas: it must be equal to '1'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 26},
-					End:      hcl.Pos{Line: 6, Column: 41},
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 9, Column: 4},
 				},
 			},
 		},
 	},
-}
-
-var deletePolicyRetentionTimeTests = []topicConfigTestCase{
 	{
-		name: "no retention on topic with delete policy",
+		name: "wrong min insync replicas",
 		input: `
-resource "kafka_topic" "topic_without_retention" {
-  name               = "topic_without_retention"
+resource "kafka_topic" "topic_with_wrong_min_insync_replicas" {
+  name               = "topic_with_wrong_min_insync_replicas"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "compression.type" = "zstd"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_without_retention" {
-  name               = "topic_without_retention"
+resource "kafka_topic" "topic_with_wrong_min_insync_replicas" {
+  name               = "topic_with_wrong_min_insync_replicas"
   replication_factor = 3
   config = {
-    "retention.ms"     = "???"
-    "cleanup.policy"   = "delete"
-    "compression.type" = "zstd"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "retention.ms must be defined on a topic with cleanup policy delete",
+				Message: "[durability] the min.insync.replicas value must be equal to '2'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 8, Column: 4},
+					Start:    hcl.Pos{Line: 9, Column: 29},
+					End:      hcl.Pos{Line: 9, Column: 32},
 				},
 			},
 		},
 	},
 	{
-		// checking that multiple fixes will be inserted correctly as the deletion policy defaults to delete and a retention template should be inserted in this case
-		name: "topic without policy and without retention time",
+		name: "compacted topic without min insync replicas",
 		input: `
-resource "kafka_topic" "topic_without_policy_and_retention" {
-  name               = "topic_without_policy_and_retention"
+resource "kafka_topic" "topic_compacted_without_min_insync_replicas" {
+  name               = "topic_compacted_without_min_insync_replicas"
   replication_factor = 3
   config = {
+    "cleanup.policy"   = "compact"
     "compression.type" = "zstd"
   }
 }`,
-		fixed: `
-resource "kafka_topic" "topic_without_policy_and_retention" {
-  name               = "topic_without_policy_and_retention"
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "correct min insync replicas",
+		input: `
+resource "kafka_topic" "topic_with_correct_min_insync_replicas" {
+  name               = "topic_with_correct_min_insync_replicas"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "???"
-    "compression.type" = "zstd"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "min insync replicas derived from the configured replication factor",
+		config: `
+rule "msk_topic_config" {
+  enabled            = true
+  replication_factor = 2
+}`,
+		input: `
+resource "kafka_topic" "topic_with_wrong_min_insync_replicas" {
+  name               = "topic_with_wrong_min_insync_replicas"
+  replication_factor = 2
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_wrong_min_insync_replicas" {
+  name               = "topic_with_wrong_min_insync_replicas"
+  replication_factor = 2
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing cleanup.policy: using default 'delete'",
+				Message: "[durability] the min.insync.replicas value must be equal to '1'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 7, Column: 4},
+					Start:    hcl.Pos{Line: 9, Column: 29},
+					End:      hcl.Pos{Line: 9, Column: 32},
 				},
 			},
+		},
+	},
+}
+
+var cleanupPolicyTests = []topicConfigTestCase{
+	{
+		name: "missing cleanup policy",
+		input: `
+resource "kafka_topic" "topic_without_cleanup_policy" {
+  name               = "topic_without_cleanup_policy"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_without_cleanup_policy" {
+  name               = "topic_without_cleanup_policy"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
 			{
-				Message: "retention.ms must be defined on a topic with cleanup policy delete",
+				Message: "[correctness] missing cleanup.policy: using default 'delete'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 7, Column: 4},
+					End:      hcl.Pos{Line: 9, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "invalid retention time",
+		name: "invalid cleanup policy value",
 		input: `
-resource "kafka_topic" "topic_with_invalid_retention" {
-  name               = "topic_with_invalid_retention"
+resource "kafka_topic" "topic_with_invalid_cleanup_policy" {
+  name               = "topic_with_invalid_cleanup_policy"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "???"
+    "cleanup.policy"   = "invalid-value"
     "compression.type" = "zstd"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "retention.ms must have a valid integer value expressed in milliseconds. Use -1 for infinite retention",
+				Message: "[correctness] invalid cleanup.policy: it must be one of [delete, compact], but currently is 'invalid-value'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 7, Column: 26},
-					End:      hcl.Pos{Line: 7, Column: 31},
+					Start:    hcl.Pos{Line: 6, Column: 26},
+					End:      hcl.Pos{Line: 6, Column: 41},
 				},
 			},
 		},
 	},
-}
-
-var deletePolicyTieredStorageTests = []topicConfigTestCase{
 	{
-		name: "retention time of 3 days requires tiered storage",
+		name: "redundant delete cleanup policy",
 		input: `
-resource "kafka_topic" "topic_with_more_than_3_days_retention" {
-  name               = "topic_with_more_than_3_days_retention"
+resource "kafka_topic" "topic_with_redundant_delete_policy" {
+  name               = "topic_with_redundant_delete_policy"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "259200000"
-    "compression.type" = "zstd"
+    "cleanup.policy"      = "delete,delete"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_more_than_3_days_retention" {
-  name               = "topic_with_more_than_3_days_retention"
+resource "kafka_topic" "topic_with_redundant_delete_policy" {
+  name               = "topic_with_redundant_delete_policy"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200000"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage must be enabled when retention time is longer than 3 days",
+				Message: "[correctness] redundant cleanup.policy: 'delete,delete' repeats the same policy, collapsing it to 'delete'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 9, Column: 4},
-				},
-			},
-			{
-				Message: "missing local.retention.ms when tiered storage is enabled: using default '86400000'",
-				Range: hcl.Range{
-					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 9, Column: 4},
+					Start:    hcl.Pos{Line: 6, Column: 29},
+					End:      hcl.Pos{Line: 6, Column: 44},
 				},
 			},
 		},
 	},
 	{
-		name: "infinite retention time requires tiered storage",
+		name: "redundant compact cleanup policy",
 		input: `
-resource "kafka_topic" "topic_with_infinite_retention" {
-  name               = "topic_with_infinite_retention"
+resource "kafka_topic" "topic_with_redundant_compact_policy" {
+  name               = "topic_with_redundant_compact_policy"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "-1"
+    "cleanup.policy"   = "compact, compact"
     "compression.type" = "zstd"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_infinite_retention" {
-  name               = "topic_with_infinite_retention"
+resource "kafka_topic" "topic_with_redundant_compact_policy" {
+  name               = "topic_with_redundant_compact_policy"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "-1"
-    "compression.type"      = "zstd"
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage must be enabled when retention time is longer than 3 days",
-				Range: hcl.Range{
-					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 9, Column: 4},
-				},
-			},
-			{
-				Message: "missing local.retention.ms when tiered storage is enabled: using default '86400000'",
+				Message: "[correctness] redundant cleanup.policy: 'compact, compact' repeats the same policy, collapsing it to 'compact'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 9, Column: 4},
+					Start:    hcl.Pos{Line: 6, Column: 26},
+					End:      hcl.Pos{Line: 6, Column: 44},
 				},
 			},
 		},
 	},
 	{
-		name: "forgot tiered storage enabling",
+		name: "combined compact and delete cleanup policy",
 		input: `
-resource "kafka_topic" "topic_with_missing_tiered_storage_enabling" {
-  name               = "topic_with_missing_tiered_storage_enabling"
+resource "kafka_topic" "topic_compact_delete" {
+  name               = "topic_compact_delete"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "259200001"
-    # keep data in primary storage for 1 day
-    "local.retention.ms" = "86400000"
+    "cleanup.policy"   = "compact,delete"
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "combined delete and compact cleanup policy",
+		input: `
+resource "kafka_topic" "topic_delete_compact" {
+  name               = "topic_delete_compact"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"   = "delete, compact"
+    "retention.ms"     = "604800000"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "combined cleanup policy without retention time",
+		input: `
+resource "kafka_topic" "topic_compact_delete" {
+  name               = "topic_compact_delete"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"   = "compact,delete"
     "compression.type" = "zstd"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_missing_tiered_storage_enabling" {
-  name               = "topic_with_missing_tiered_storage_enabling"
+resource "kafka_topic" "topic_compact_delete" {
+  name               = "topic_compact_delete"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
-    # keep data in primary storage for 1 day
-    "local.retention.ms" = "86400000"
-    "compression.type"   = "zstd"
+    "retention.ms"     = "???"
+    "cleanup.policy"   = "compact,delete"
+    "compression.type" = "zstd"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage must be enabled when retention time is longer than 3 days",
+				Message: "[durability] retention.ms must be defined on a topic with cleanup policy delete",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 11, Column: 4},
+					End:      hcl.Pos{Line: 8, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "tiered storage disabled for retention period bigger than 3 days",
+		name: "combined cleanup policy with tiered storage",
 		input: `
-resource "kafka_topic" "topic_with_more_than_3_days_retention_tiered_disabled" {
-  name               = "topic_with_more_than_3_days_retention_tiered_disabled"
+resource "kafka_topic" "topic_compact_delete" {
+  name               = "topic_compact_delete"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "false"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "compact,delete"
+    "retention.ms"          = "604800000"
     "compression.type"      = "zstd"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_more_than_3_days_retention_tiered_disabled" {
-  name               = "topic_with_more_than_3_days_retention_tiered_disabled"
+resource "kafka_topic" "topic_compact_delete" {
+  name               = "topic_compact_delete"
   replication_factor = 3
   config = {
-    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
-    "compression.type"      = "zstd"
+
+    "cleanup.policy"   = "compact,delete"
+    "retention.ms"     = "604800000"
+    "compression.type" = "zstd"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage must be enabled when retention time is longer than 3 days",
+				Message: "[correctness] tiered storage is not supported for compacted topic: disabling it...",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 38},
+					End:      hcl.Pos{Line: 6, Column: 37},
 				},
 			},
-			{
-				Message: "missing local.retention.ms when tiered storage is enabled: using default '86400000'",
-				Range: hcl.Range{
-					Filename: fileName,
-					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 10, Column: 4},
+		},
+	},
+}
+
+var deletePolicyRetentionTimeTests = []topicConfigTestCase{
+	{
+		name: "no retention on topic with delete policy",
+		input: `
+resource "kafka_topic" "topic_without_retention" {
+  name               = "topic_without_retention"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_without_retention" {
+  name               = "topic_without_retention"
+  replication_factor = 3
+  config = {
+    "retention.ms"        = "???"
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[durability] retention.ms must be defined on a topic with cleanup policy delete",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 9, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "tiered storage enabled without local retention",
+		// checking that multiple fixes will be inserted correctly as the deletion policy defaults to delete and a retention template should be inserted in this case
+		name: "topic without policy and without retention time",
 		input: `
-resource "kafka_topic" "topic_with_tiered_storage_missing_local_retention" {
-  name               = "topic_with_tiered_storage_missing_local_retention"
+resource "kafka_topic" "topic_without_policy_and_retention" {
+  name               = "topic_without_policy_and_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
-    "compression.type"      = "zstd"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_tiered_storage_missing_local_retention" {
-  name               = "topic_with_tiered_storage_missing_local_retention"
+resource "kafka_topic" "topic_without_policy_and_retention" {
+  name               = "topic_without_policy_and_retention"
   replication_factor = 3
   config = {
-    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "???"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "missing local.retention.ms when tiered storage is enabled: using default '86400000'",
+				Message: "[correctness] missing cleanup.policy: using default 'delete'",
 				Range: hcl.Range{
 					Filename: fileName,
 					Start:    hcl.Pos{Line: 5, Column: 3},
-					End:      hcl.Pos{Line: 10, Column: 4},
+					End:      hcl.Pos{Line: 8, Column: 4},
+				},
+			},
+			{
+				Message: "[durability] retention.ms must be defined on a topic with cleanup policy delete",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 8, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "tiered storage enabled and local retention invalid",
+		name: "invalid retention time",
 		input: `
-resource "kafka_topic" "topic_with_tiered_storage_local_retention_invalid" {
-  name               = "topic_with_tiered_storage_local_retention_invalid"
+resource "kafka_topic" "topic_with_invalid_retention" {
+  name               = "topic_with_invalid_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "259200001"
-    "local.retention.ms"    = "invalid-val"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "???"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "local.retention.ms must have a valid integer value expressed in milliseconds",
+				Message: "[correctness] retention.ms must have a valid integer value expressed in milliseconds. Use -1 for infinite retention",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 9, Column: 31},
-					End:      hcl.Pos{Line: 9, Column: 44},
+					Start:    hcl.Pos{Line: 7, Column: 29},
+					End:      hcl.Pos{Line: 7, Column: 34},
 				},
 			},
 		},
 	},
 	{
-		name: "tiered storage enabled for less than 3 days retention",
+		name: "retention time as a whole float",
 		input: `
-resource "kafka_topic" "topic_with_less_3_days_retention_with_remote_storage" {
-  name               = "topic_with_less_3_days_retention_with_remote_storage"
+resource "kafka_topic" "topic_with_float_retention" {
+  name               = "topic_with_float_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "86400000"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000.0"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_less_3_days_retention_with_remote_storage" {
-  name               = "topic_with_less_3_days_retention_with_remote_storage"
+resource "kafka_topic" "topic_with_float_retention" {
+  name               = "topic_with_float_retention"
   replication_factor = 3
   config = {
-
-    "cleanup.policy"   = "delete"
-    "retention.ms"     = "86400000"
-    "compression.type" = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage is not supported for less than 3 days retention: disabling it...",
+				Message: "[correctness] retention.ms must have an integer value expressed in milliseconds: converting '86400000.0' to '86400000'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 37},
+					Start:    hcl.Pos{Line: 7, Column: 29},
+					End:      hcl.Pos{Line: 7, Column: 41},
 				},
 			},
 		},
 	},
 	{
-		name: "tiered storage explicitly disabled for less than 3 days retention",
+		name: "retention time as a fractional float",
 		input: `
-resource "kafka_topic" "topic_with_less_3_days_retention_with_disabled_remote_storage" {
-  name               = "topic_with_less_3_days_retention_with_disabled_remote_storage"
+resource "kafka_topic" "topic_with_fractional_retention" {
+  name               = "topic_with_fractional_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "false"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "86400000"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000.5"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
-		expected: []*helper.Issue{},
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] retention.ms must have an integer value expressed in milliseconds, but '86400000.5' has a fractional part",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 29},
+					End:      hcl.Pos{Line: 7, Column: 41},
+				},
+			},
+		},
 	},
 	{
-		name: "local storage specified for less than 3 days retention",
+		name: "delete retention time on topic with delete policy",
 		input: `
-resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
-  name               = "topic_with_less_3_days_retention_with_local_storage"
+resource "kafka_topic" "topic_with_delete_retention" {
+  name               = "topic_with_delete_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "delete"
-    "retention.ms"          = "172800000"
-    "local.retention.ms"    = "86400000"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "delete.retention.ms" = "3600000" # keep tombstones for 1 hour
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
-  name               = "topic_with_less_3_days_retention_with_local_storage"
+resource "kafka_topic" "topic_with_delete_retention" {
+  name               = "topic_with_delete_retention"
   replication_factor = 3
   config = {
-
     "cleanup.policy" = "delete"
-    "retention.ms"   = "172800000"
+    "retention.ms"   = "86400000"
 
-    "compression.type" = "zstd"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage is not supported for less than 3 days retention: disabling it...",
-				Range: hcl.Range{
-					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 37},
-				},
-			},
-			{
-				Message: "defining local.retention.ms is misleading when tiered storage is disabled due to less than 3 days retention: removing it...",
+				Message: "[correctness] defining delete.retention.ms is misleading for delete policy: removing it...",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 9, Column: 31},
-					End:      hcl.Pos{Line: 9, Column: 41},
+					Start:    hcl.Pos{Line: 8, Column: 5},
+					End:      hcl.Pos{Line: 8, Column: 26},
 				},
 			},
 		},
 	},
 }
 
-var compactPolicyTests = []topicConfigTestCase{
+var deletePolicyTieredStorageTests = []topicConfigTestCase{
 	{
-		name: "tiered storage specified for compacted topic",
+		name: "retention time of 3 days requires tiered storage",
 		input: `
-resource "kafka_topic" "topic_compacted_with_tiered_storage" {
-  name               = "topic_compacted_with_tiered_storage"
+resource "kafka_topic" "topic_with_more_than_3_days_retention" {
+  name               = "topic_with_more_than_3_days_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "cleanup.policy"        = "compact"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "259200000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_compacted_with_tiered_storage" {
-  name               = "topic_compacted_with_tiered_storage"
+resource "kafka_topic" "topic_with_more_than_3_days_retention" {
+  name               = "topic_with_more_than_3_days_retention"
   replication_factor = 3
   config = {
-
-    "cleanup.policy"   = "compact"
-    "compression.type" = "zstd"
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage is not supported for compacted topic: disabling it...",
+				Message: "[cost] tiered storage must be enabled when retention time is longer than 3 days",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 37},
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 10, Column: 4},
+				},
+			},
+			{
+				Message: "[cost] missing local.retention.ms when tiered storage is enabled: using default '86400000'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 10, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "local storage specified for compacted topic",
+		name: "infinite retention time requires tiered storage",
 		input: `
-resource "kafka_topic" "topic_compacted_with_local_storage" {
-  name               = "topic_compacted_with_local_storage"
+resource "kafka_topic" "topic_with_infinite_retention" {
+  name               = "topic_with_infinite_retention"
   replication_factor = 3
   config = {
-    "remote.storage.enable" = "true"
-    "local.retention.ms"    = "86400000"
-    "cleanup.policy"        = "compact"
-    "compression.type"      = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "-1"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_compacted_with_local_storage" {
-  name               = "topic_compacted_with_local_storage"
+resource "kafka_topic" "topic_with_infinite_retention" {
+  name               = "topic_with_infinite_retention"
   replication_factor = 3
   config = {
-
-
-    "cleanup.policy"   = "compact"
-    "compression.type" = "zstd"
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "-1"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "tiered storage is not supported for compacted topic: disabling it...",
+				Message: "[cost] tiered storage must be enabled when retention time is longer than 3 days",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 31},
-					End:      hcl.Pos{Line: 6, Column: 37},
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 10, Column: 4},
 				},
 			},
 			{
-				Message: "defining local.retention.ms is misleading when tiered storage is disabled due to compacted topic: removing it...",
+				Message: "[cost] missing local.retention.ms when tiered storage is enabled: using default '86400000'",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 7, Column: 31},
-					End:      hcl.Pos{Line: 7, Column: 41},
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 10, Column: 4},
 				},
 			},
 		},
 	},
 	{
-		name: "retention time specified for compacted topic",
+		name: "forgot tiered storage enabling",
 		input: `
-resource "kafka_topic" "topic_compacted_with_retention_time" {
-  name               = "topic_compacted_with_retention_time"
+resource "kafka_topic" "topic_with_missing_tiered_storage_enabling" {
+  name               = "topic_with_missing_tiered_storage_enabling"
   replication_factor = 3
   config = {
-    "retention.ms"     = "86400000"
-    "cleanup.policy"   = "compact"
-    "compression.type" = "zstd"
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "259200001"
+    # keep data in primary storage for 1 day
+    "local.retention.ms"  = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		fixed: `
-resource "kafka_topic" "topic_compacted_with_retention_time" {
-  name               = "topic_compacted_with_retention_time"
+resource "kafka_topic" "topic_with_missing_tiered_storage_enabling" {
+  name               = "topic_with_missing_tiered_storage_enabling"
   replication_factor = 3
   config = {
-
-    "cleanup.policy"   = "compact"
-    "compression.type" = "zstd"
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200001"
+    # keep data in primary storage for 1 day
+    "local.retention.ms"  = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
   }
 }`,
 		expected: []*helper.Issue{
 			{
-				Message: "defining retention.ms is misleading for compacted topic: removing it...",
+				Message: "[cost] tiered storage must be enabled when retention time is longer than 3 days",
 				Range: hcl.Range{
 					Filename: fileName,
-					Start:    hcl.Pos{Line: 6, Column: 5},
-					End:      hcl.Pos{Line: 6, Column: 19},
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 12, Column: 4},
 				},
 			},
 		},
 	},
-}
-
-var goodConfigTests = []topicConfigTestCase{
 	{
-		name: "good topic definition without retention",
+		name: "tiered storage disabled for retention period bigger than 3 days",
 		input: `
-resource "kafka_topic" "good topic" {
-  name               = "good_topic"
+resource "kafka_topic" "topic_with_more_than_3_days_retention_tiered_disabled" {
+  name               = "topic_with_more_than_3_days_retention_tiered_disabled"
   replication_factor = 3
   config = {
-    "cleanup.policy"   = "delete"
-    "compression.type" = "zstd"
-    "retention.ms"     = "86400000"
+    "remote.storage.enable" = "false"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200001"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
   }
 }`,
-		expected: []*helper.Issue{},
-	},
-	{
-		name: "good topic definition with retention",
-		input: `
-resource "kafka_topic" "good topic" {
-  name               = "good_topic"
+		fixed: `
+resource "kafka_topic" "topic_with_more_than_3_days_retention_tiered_disabled" {
+  name               = "topic_with_more_than_3_days_retention_tiered_disabled"
   replication_factor = 3
   config = {
-    # keep data in primary storage for 1 day
-    "local.retention.ms"    = "86400000"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
     "remote.storage.enable" = "true"
     "cleanup.policy"        = "delete"
-    "retention.ms"          = "2592000000"
+    "retention.ms"          = "259200001"
     "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
   }
 }`,
-		expected: []*helper.Issue{},
-	},
-	{
-		name: "good compacted topic definition",
-		input: `
-resource "kafka_topic" "good topic" {
-  name               = "good_topic"
-  replication_factor = 3
-  config = {
-    "cleanup.policy"   = "compact"
-    "compression.type" = "zstd"
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] tiered storage must be enabled when retention time is longer than 3 days",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 38},
+				},
+			},
+			{
+				Message: "[cost] missing local.retention.ms when tiered storage is enabled: using default '86400000'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 11, Column: 4},
+				},
+			},
+		},
+	},
+	{
+		name: "tiered storage enabled without local retention",
+		input: `
+resource "kafka_topic" "topic_with_tiered_storage_missing_local_retention" {
+  name               = "topic_with_tiered_storage_missing_local_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200001"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_tiered_storage_missing_local_retention" {
+  name               = "topic_with_tiered_storage_missing_local_retention"
+  replication_factor = 3
+  config = {
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200001"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] missing local.retention.ms when tiered storage is enabled: using default '86400000'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 11, Column: 4},
+				},
+			},
+		},
+	},
+	{
+		name: "tiered storage enabled and local retention invalid",
+		input: `
+resource "kafka_topic" "topic_with_tiered_storage_local_retention_invalid" {
+  name               = "topic_with_tiered_storage_local_retention_invalid"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "259200001"
+    "local.retention.ms"    = "invalid-val"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] local.retention.ms must have a valid integer value expressed in milliseconds",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 9, Column: 31},
+					End:      hcl.Pos{Line: 9, Column: 44},
+				},
+			},
+		},
+	},
+	{
+		name: "tiered storage enabled for less than 3 days retention",
+		input: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_remote_storage" {
+  name               = "topic_with_less_3_days_retention_with_remote_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "86400000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_remote_storage" {
+  name               = "topic_with_less_3_days_retention_with_remote_storage"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for less than 3 days retention: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+		},
+	},
+	{
+		name: "tiered storage explicitly disabled for less than 3 days retention",
+		input: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_disabled_remote_storage" {
+  name               = "topic_with_less_3_days_retention_with_disabled_remote_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "false"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "86400000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "local storage specified for less than 3 days retention",
+		input: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
+  name               = "topic_with_less_3_days_retention_with_local_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "172800000"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
+  name               = "topic_with_less_3_days_retention_with_local_storage"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "172800000"
+
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for less than 3 days retention: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+			{
+				Message: "[correctness] defining local.retention.ms is misleading when tiered storage is disabled due to less than 3 days retention: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 9, Column: 31},
+					End:      hcl.Pos{Line: 9, Column: 41},
+				},
+			},
+		},
+	},
+	{
+		name: "local storage with comment specified for less than 3 days retention",
+		input: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
+  name               = "topic_with_less_3_days_retention_with_local_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "172800000"
+    # keep data in primary storage for 1 day
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_less_3_days_retention_with_local_storage" {
+  name               = "topic_with_less_3_days_retention_with_local_storage"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "172800000"
+
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for less than 3 days retention: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+			{
+				Message: "[correctness] defining local.retention.ms is misleading when tiered storage is disabled due to less than 3 days retention: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 10, Column: 31},
+					End:      hcl.Pos{Line: 10, Column: 41},
+				},
+			},
+		},
+	},
+}
+
+var ruleConfigTests = []topicConfigTestCase{
+	{
+		name: "retention time with overridden previous line comment specified for compacted topic",
+		config: `
+rule "msk_topic_config_comments" {
+  enabled       = true
+  base_comments = {
+    "retention.ms" = "retain data"
+  }
+}`,
+		input: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+    # retain data for 1 week
+    "retention.ms"     = "604800000"
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] defining retention.ms is misleading for compacted topic: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "configured replication factor is missing",
+		config: `
+rule "msk_topic_config" {
+  enabled            = true
+  replication_factor = 2
+}`,
+		input: `
+resource "kafka_topic" "topic_without_repl_factor" {
+  name = "topic_without_repl_factor"
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_without_repl_factor" {
+  name               = "topic_without_repl_factor"
+  replication_factor = 2
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[durability] missing replication_factor: it must be equal to '2'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 2, Column: 1},
+					End:      hcl.Pos{Line: 2, Column: 51},
+				},
+			},
+		},
+	},
+	{
+		name: "configured replication factor is different",
+		config: `
+rule "msk_topic_config" {
+  enabled            = true
+  replication_factor = 2
+}`,
+		input: `
+resource "kafka_topic" "topic_with_incorrect_repl_factor" {
+  name               = "topic_with_incorrect_repl_factor"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_incorrect_repl_factor" {
+  name               = "topic_with_incorrect_repl_factor"
+  replication_factor = 2
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "1"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[durability] the replication_factor must be equal to '2'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 4, Column: 3},
+					End:      hcl.Pos{Line: 4, Column: 25},
+				},
+			},
+		},
+	},
+	{
+		name: "configured default local retention time",
+		config: `
+rule "msk_topic_config" {
+  enabled                      = true
+  default_local_retention_days = 2
+}`,
+		input: `
+resource "kafka_topic" "topic_with_tiered_storage" {
+  name               = "topic_with_tiered_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_tiered_storage" {
+  name               = "topic_with_tiered_storage"
+  replication_factor = 3
+  config = {
+    "local.retention.ms"    = "172800000" # keep data in primary storage for 2 days
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] missing local.retention.ms when tiered storage is enabled: using default '172800000'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 11, Column: 4},
+				},
+			},
+		},
+	},
+	{
+		name: "configured allowed compression type different from the default",
+		config: `
+rule "msk_topic_config" {
+  enabled                   = true
+  allowed_compression_types = ["zstd", "lz4"]
+}`,
+		input: `
+resource "kafka_topic" "topic_with_lz4" {
+  name               = "topic_with_lz4"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "lz4"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "compression type not in the configured allowed ones",
+		config: `
+rule "msk_topic_config" {
+  enabled                   = true
+  allowed_compression_types = ["zstd", "lz4", "snappy"]
+  default_compression_type  = "lz4"
+}`,
+		input: `
+resource "kafka_topic" "topic_with_gzip" {
+  name               = "topic_with_gzip"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "gzip"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_gzip" {
+  name               = "topic_with_gzip"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "lz4"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] the compression.type value must be one of 'zstd', 'lz4', 'snappy'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 29},
+					End:      hcl.Pos{Line: 6, Column: 35},
+				},
+			},
+		},
+	},
+	{
+		name: "missing compression type set to the configured default",
+		config: `
+rule "msk_topic_config" {
+  enabled                   = true
+  allowed_compression_types = ["snappy", "lz4"]
+}`,
+		input: `
+resource "kafka_topic" "topic_without_compression" {
+  name               = "topic_without_compression"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_without_compression" {
+  name               = "topic_without_compression"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "snappy"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] missing compression.type: it must be one of 'snappy', 'lz4'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 9, Column: 4},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time above the configured maximum",
+		config: `
+rule "msk_topic_config" {
+  enabled          = true
+  max_retention_ms = 31536000000
+}`,
+		input: `
+resource "kafka_topic" "topic_with_long_retention" {
+  name               = "topic_with_long_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "63072000000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] retention.ms '63072000000' (2 years) exceeds the maximum retention '31536000000' (1 year)",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 10, Column: 31},
+					End:      hcl.Pos{Line: 10, Column: 44},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time equal to the configured maximum",
+		config: `
+rule "msk_topic_config" {
+  enabled          = true
+  max_retention_ms = 31536000000
+}`,
+		input: `
+resource "kafka_topic" "topic_with_max_retention" {
+  name               = "topic_with_max_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "31536000000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "infinite retention time with a configured maximum",
+		config: `
+rule "msk_topic_config" {
+  enabled          = true
+  max_retention_ms = 31536000000
+}`,
+		input: `
+resource "kafka_topic" "topic_with_infinite_retention" {
+  name               = "topic_with_infinite_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "-1"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "long retention time without a configured maximum",
+		input: `
+resource "kafka_topic" "topic_with_long_retention" {
+  name               = "topic_with_long_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "63072000000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "retention time under a configured tiered storage threshold",
+		config: `
+rule "msk_topic_config" {
+  enabled                       = true
+  tiered_storage_threshold_days = 7
+}`,
+		input: `
+resource "kafka_topic" "topic_with_5_days_retention" {
+  name               = "topic_with_5_days_retention"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "432000000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "tiered storage enabled under a configured tiered storage threshold",
+		config: `
+rule "msk_topic_config" {
+  enabled                       = true
+  tiered_storage_threshold_days = 7
+}`,
+		input: `
+resource "kafka_topic" "topic_with_5_days_retention" {
+  name               = "topic_with_5_days_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "432000000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_5_days_retention" {
+  name               = "topic_with_5_days_retention"
+  replication_factor = 3
+  config = {
+
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "432000000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for less than 7 days retention: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time over a configured tiered storage threshold",
+		config: `
+rule "msk_topic_config" {
+  enabled                       = true
+  tiered_storage_threshold_days = 7
+}`,
+		input: `
+resource "kafka_topic" "topic_with_7_days_retention" {
+  name               = "topic_with_7_days_retention"
+  replication_factor = 3
+  config = {
+    "local.retention.ms"  = "86400000"
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "604800000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_7_days_retention" {
+  name               = "topic_with_7_days_retention"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "compression.type"      = "zstd"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "604800000"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] tiered storage must be enabled when retention time is longer than 7 days",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 11, Column: 4},
+				},
+			},
+		},
+	},
+	{
+		name: "topic with a custom resource type",
+		config: `
+rule "msk_topic_name" {
+  enabled             = true
+  topic_resource_type = "uw_kafka_topic"
+}`,
+		input: `
+resource "uw_kafka_topic" "topic_with_custom_type" {
+  name               = "topic_with_custom_type"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "uw_kafka_topic" "topic_with_custom_type" {
+  name               = "topic_with_custom_type"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[cost] missing compression.type: it must be equal to 'zstd'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 5, Column: 3},
+					End:      hcl.Pos{Line: 9, Column: 4},
+				},
+			},
+		},
+	},
+}
+
+var compactPolicyTests = []topicConfigTestCase{
+	{
+		name: "tiered storage specified for compacted topic",
+		input: `
+resource "kafka_topic" "topic_compacted_with_tiered_storage" {
+  name               = "topic_compacted_with_tiered_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "compact"
+    "compression.type"      = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_tiered_storage" {
+  name               = "topic_compacted_with_tiered_storage"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for compacted topic: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+		},
+	},
+	{
+		name: "local storage specified for compacted topic",
+		input: `
+resource "kafka_topic" "topic_compacted_with_local_storage" {
+  name               = "topic_compacted_with_local_storage"
+  replication_factor = 3
+  config = {
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000"
+    "cleanup.policy"        = "compact"
+    "compression.type"      = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_local_storage" {
+  name               = "topic_compacted_with_local_storage"
+  replication_factor = 3
+  config = {
+
+
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] tiered storage is not supported for compacted topic: disabling it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 31},
+					End:      hcl.Pos{Line: 6, Column: 37},
+				},
+			},
+			{
+				Message: "[correctness] defining local.retention.ms is misleading when tiered storage is disabled due to compacted topic: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 31},
+					End:      hcl.Pos{Line: 7, Column: 41},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time specified for compacted topic",
+		input: `
+resource "kafka_topic" "topic_compacted_with_retention_time" {
+  name               = "topic_compacted_with_retention_time"
+  replication_factor = 3
+  config = {
+    "retention.ms"     = "86400000"
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_retention_time" {
+  name               = "topic_compacted_with_retention_time"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] defining retention.ms is misleading for compacted topic: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time with inline comment specified for compacted topic",
+		input: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+    "retention.ms"     = "604800000" # keep data for 7 days
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] defining retention.ms is misleading for compacted topic: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 6, Column: 5},
+					End:      hcl.Pos{Line: 6, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "retention time with previous line comment specified for compacted topic",
+		input: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+    # keep data for 7 days
+    "retention.ms"        = "604800000"
+    # compaction keeps the latest value per key
+    "cleanup.policy"      = "compact"
+    "compression.type"    = "zstd"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_compacted_with_commented_retention_time" {
+  name               = "topic_compacted_with_commented_retention_time"
+  replication_factor = 3
+  config = {
+
+    # compaction keeps the latest value per key
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] defining retention.ms is misleading for compacted topic: removing it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 19},
+				},
+			},
+		},
+	},
+	{
+		name: "min compaction lag greater than max compaction lag",
+		input: `
+resource "kafka_topic" "topic_compacted_with_wrong_lags" {
+  name               = "topic_compacted_with_wrong_lags"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"        = "compact"
+    "compression.type"      = "zstd"
+    "min.compaction.lag.ms" = "86400000"
+    "max.compaction.lag.ms" = "3600000"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] min.compaction.lag.ms '86400000' must not be greater than max.compaction.lag.ms '3600000', as the brokers reject such a config",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 8, Column: 31},
+					End:      hcl.Pos{Line: 8, Column: 41},
+				},
+			},
+		},
+	},
+	{
+		name: "min compaction lag less than max compaction lag",
+		input: `
+resource "kafka_topic" "topic_compacted_with_lags" {
+  name               = "topic_compacted_with_lags"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"        = "compact"
+    "compression.type"      = "zstd"
+    "min.compaction.lag.ms" = "3600000"
+    "max.compaction.lag.ms" = "86400000"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+}
+
+var duplicateKeysTests = []topicConfigTestCase{
+	{
+		name: "config key defined more than once",
+		input: `
+resource "kafka_topic" "topic_with_duplicate_retention" {
+  name               = "topic_with_duplicate_retention"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "172800000"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] retention.ms is defined more than once: this definition is overridden by the effective value '86400000'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 19},
+				},
+			},
+		},
+	},
+}
+
+var paddedKeysTests = []topicConfigTestCase{
+	{
+		name: "config key with trailing whitespace",
+		input: `
+resource "kafka_topic" "topic_with_padded_key" {
+  name               = "topic_with_padded_key"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms "       = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_padded_key" {
+  name               = "topic_with_padded_key"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] config key 'retention.ms ' has leading or trailing whitespace, which makes it a distinct config from 'retention.ms': trimming it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 7, Column: 5},
+					End:      hcl.Pos{Line: 7, Column: 20},
+				},
+			},
+		},
+	},
+	{
+		name: "config key with leading whitespace also defined trimmed",
+		input: `
+resource "kafka_topic" "topic_with_padded_key" {
+  name               = "topic_with_padded_key"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    " compression.type"   = "zstd"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		fixed: `
+resource "kafka_topic" "topic_with_padded_key" {
+  name               = "topic_with_padded_key"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+    "compression.type"    = "zstd"
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{
+			{
+				Message: "[correctness] compression.type is defined more than once: this definition is overridden by the effective value 'zstd'",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 8, Column: 5},
+					End:      hcl.Pos{Line: 8, Column: 24},
+				},
+			},
+			{
+				Message: "[correctness] config key ' compression.type' has leading or trailing whitespace, which makes it a distinct config from 'compression.type': trimming it...",
+				Range: hcl.Range{
+					Filename: fileName,
+					Start:    hcl.Pos{Line: 8, Column: 5},
+					End:      hcl.Pos{Line: 8, Column: 24},
+				},
+			},
+		},
+	},
+}
+
+var goodConfigTests = []topicConfigTestCase{
+	{
+		name: "good topic definition without retention",
+		input: `
+resource "kafka_topic" "good topic" {
+  name               = "good_topic"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "compression.type"    = "zstd"
+    "retention.ms"        = "86400000"
+    "min.insync.replicas" = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "good topic definition with retention",
+		input: `
+resource "kafka_topic" "good topic" {
+  name               = "good_topic"
+  replication_factor = 3
+  config = {
+    # keep data in primary storage for 1 day
+    "local.retention.ms"    = "86400000"
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+  }
+}`,
+		expected: []*helper.Issue{},
+	},
+	{
+		name: "good compacted topic definition",
+		input: `
+resource "kafka_topic" "good topic" {
+  name               = "good_topic"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"   = "compact"
+    "compression.type" = "zstd"
   }
 }`,
 		expected: []*helper.Issue{},
@@ -842,32 +2114,454 @@ func Test_MSKTopicConfigRule(t *testing.T) {
 	var allTests []topicConfigTestCase
 	allTests = append(allTests, replicationFactorTests...)
 	allTests = append(allTests, compressionTypeTests...)
+	allTests = append(allTests, minInSyncReplicasTests...)
 	allTests = append(allTests, cleanupPolicyTests...)
 	allTests = append(allTests, deletePolicyRetentionTimeTests...)
 	allTests = append(allTests, deletePolicyTieredStorageTests...)
 	allTests = append(allTests, compactPolicyTests...)
+	allTests = append(allTests, duplicateKeysTests...)
+	allTests = append(allTests, paddedKeysTests...)
+	allTests = append(allTests, ruleConfigTests...)
 	allTests = append(allTests, goodConfigTests...)
 
-	for _, tc := range allTests {
-		t.Run(tc.name, func(t *testing.T) {
-			runner := helper.TestRunner(t, map[string]string{fileName: tc.input})
-			require.NoError(t, rule.Check(runner))
+	runTopicConfigTests(t, rule, allTests)
+}
 
-			setExpectedRule(tc.expected, rule)
-			helper.AssertIssues(t, tc.expected, runner.Issues)
+func Test_MSKTopicConfigRuleNoTopics(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
 
-			if tc.fixed != "" {
-				t.Logf("Proposed changes: %s", string(runner.Changes()[fileName]))
-				helper.AssertChanges(t, map[string]string{fileName: tc.fixed}, runner.Changes())
-			} else {
-				assert.Empty(t, runner.Changes())
-			}
-		})
-	}
+	runner := helper.TestRunner(t, map[string]string{"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk/pubsub/tfstate"
+    region = "eu-west-1"
+  }
+}
+`})
+	require.NoError(t, rule.Check(runner))
+
+	assert.Empty(t, runner.Issues)
+	assert.Empty(t, runner.Changes())
+}
+
+func Test_MSKTopicConfigRuleModuleOutputConfig(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{fileName: `
+module "defaults" {
+  source = "../defaults"
+}
+
+resource "kafka_topic" "topic_def" {
+  name               = "topic_def"
+  replication_factor = 3
+  config             = module.defaults.topic_config
+}`})
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] skipping the config checks, as the config is assigned from the module output 'module.defaults.topic_config' which can't be statically analyzed",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 9, Column: 3},
+				End:      hcl.Pos{Line: 9, Column: 52},
+			},
+		},
+	}, runner.Issues)
+	assert.Empty(t, runner.Changes())
+}
+
+func Test_MSKTopicConfigRuleNonObjectConfig(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{fileName: `
+resource "kafka_topic" "from_var" {
+  name               = "from_var"
+  replication_factor = 3
+  config             = var.topic_config
+}
+
+resource "kafka_topic" "json_encoded" {
+  name               = "json_encoded"
+  replication_factor = 3
+  config             = jsonencode({ "cleanup.policy" = "compact" })
+}
+
+resource "kafka_topic" "wrong_compression" {
+  name               = "wrong_compression"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"   = "compact"
+    "compression.type" = "gzip"
+  }
+}`})
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] config must be an inline object literal for linting: skipping the config checks",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 5, Column: 3},
+				End:      hcl.Pos{Line: 5, Column: 40},
+			},
+		},
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] config must be an inline object literal for linting: skipping the config checks",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 11, Column: 3},
+				End:      hcl.Pos{Line: 11, Column: 68},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[cost] the compression.type value must be equal to 'zstd'",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 19, Column: 26},
+				End:      hcl.Pos{Line: 19, Column: 32},
+			},
+		},
+	}, runner.Issues)
 }
 
+func Test_MSKTopicConfigRuleMergedConfigKeys(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{fileName: `
+locals {
+  base_config = {
+    "cleanup.policy"   = "delete"
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}
+
+resource "kafka_topic" "merge_override" {
+  name               = "merge_override"
+  replication_factor = 3
+  config = merge(local.base_config, {
+    "retention.ms" = "172800000"
+  })
+}
+
+resource "kafka_topic" "merge_inline_objects" {
+  name               = "merge_inline_objects"
+  replication_factor = 3
+  config = merge(
+    { "cleanup.policy" = "delete", "retention.ms" = "86400000" },
+    { "retention.ms" = var.retention_ms },
+  )
+}`})
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[correctness] retention.ms is defined more than once in merge(): this definition is overridden by the effective value '172800000'",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 13, Column: 18},
+				End:      hcl.Pos{Line: 13, Column: 35},
+			},
+		},
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] config must be an inline object literal for linting: skipping the config checks",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 13, Column: 3},
+				End:      hcl.Pos{Line: 15, Column: 5},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[correctness] retention.ms is defined more than once in merge(): this definition is overridden by the effective value 'var.retention_ms'",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 22, Column: 36},
+				End:      hcl.Pos{Line: 22, Column: 50},
+			},
+		},
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] config must be an inline object literal for linting: skipping the config checks",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 21, Column: 3},
+				End:      hcl.Pos{Line: 24, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_MSKTopicConfigRuleInvalidReplicationFactor(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "msk_topic_config" {
+  enabled            = true
+  replication_factor = 0
+}`,
+	})
+
+	require.EqualError(t, rule.Check(runner), "replication_factor must be a positive integer, got 0")
+}
+
+func Test_MSKTopicConfigRuleInvalidTieredStorageThreshold(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "msk_topic_config" {
+  enabled                       = true
+  tiered_storage_threshold_days = 0
+}`,
+	})
+
+	require.EqualError(t, rule.Check(runner), "tiered_storage_threshold_days must be a positive integer, got 0")
+}
+
+func Test_MSKTopicConfigRuleReferencedValues(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	runner := helper.TestRunner(t, map[string]string{fileName: `
+locals {
+  one_day_ms = "86400000"
+}
+
+resource "kafka_topic" "referenced_retention" {
+  name               = "referenced_retention"
+  replication_factor = 3
+  config = {
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = local.one_day_ms
+    "min.insync.replicas" = var.min_insync_replicas
+  }
+}`})
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] skipping the checks of the retention.ms value, as it references 'local.one_day_ms' which can't be statically analyzed",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 11, Column: 29},
+				End:      hcl.Pos{Line: 11, Column: 45},
+			},
+		},
+		{
+			Rule:    withSeverity(rule, tflint.NOTICE),
+			Message: "[correctness] skipping the checks of the min.insync.replicas value, as it references 'var.min_insync_replicas' which can't be statically analyzed",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 12, Column: 29},
+				End:      hcl.Pos{Line: 12, Column: 52},
+			},
+		},
+		{
+			Rule:    rule,
+			Message: "[cost] missing compression.type: it must be equal to 'zstd'",
+			Range: hcl.Range{
+				Filename: fileName,
+				Start:    hcl.Pos{Line: 9, Column: 3},
+				End:      hcl.Pos{Line: 13, Column: 4},
+			},
+		},
+	}, runner.Issues)
+	helper.AssertChanges(t, map[string]string{fileName: `
+locals {
+  one_day_ms = "86400000"
+}
+
+resource "kafka_topic" "referenced_retention" {
+  name               = "referenced_retention"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = local.one_day_ms
+    "min.insync.replicas" = var.min_insync_replicas
+  }
+}`}, runner.Changes())
+}
+
+// setExpectedRule sets the rule of the expected issues which don't define one, like a rule with another severity.
 func setExpectedRule(expected helper.Issues, rule tflint.Rule) {
 	for _, exp := range expected {
-		exp.Rule = rule
+		if exp.Rule == nil {
+			exp.Rule = rule
+		}
+	}
+}
+
+func Test_MSKTopicConfigRuleCombinedFixes(t *testing.T) {
+	rule := &MSKTopicConfigRule{}
+
+	for _, tc := range []struct {
+		name  string
+		input string
+		fixed string
+	}{
+		{
+			name: "config missing several keys",
+			input: `
+resource "kafka_topic" "topic_missing_keys" {
+  name = "topic_missing_keys"
+  config = {
+    "retention.ms" = "86400000"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_missing_keys" {
+  name               = "topic_missing_keys"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+  }
+}`,
+		},
+		{
+			name: "empty config",
+			input: `
+resource "kafka_topic" "topic_empty_config" {
+  name   = "topic_empty_config"
+  config = {}
+}`,
+			fixed: `
+resource "kafka_topic" "topic_empty_config" {
+  name               = "topic_empty_config"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "???"
+  }
+}`,
+		},
+		{
+			name: "single line config missing several keys",
+			input: `
+resource "kafka_topic" "topic_single_line_config" {
+  name               = "topic_single_line_config"
+  replication_factor = 3
+  config             = { "retention.ms" = "86400000" }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_single_line_config" {
+  name               = "topic_single_line_config"
+  replication_factor = 3
+  config = {
+    "compression.type"    = "zstd"
+    "min.insync.replicas" = "2"
+    "cleanup.policy"      = "delete"
+    "retention.ms"        = "86400000"
+  }
+}`,
+		},
+		{
+			name: "tiered storage missing several keys",
+			input: `
+resource "kafka_topic" "topic_tiered_missing_keys" {
+  name = "topic_tiered_missing_keys"
+  config = {
+    "retention.ms" = "604800000"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_tiered_missing_keys" {
+  name               = "topic_tiered_missing_keys"
+  replication_factor = 3
+  config = {
+    "compression.type"      = "zstd"
+    "min.insync.replicas"   = "2"
+    "cleanup.policy"        = "delete"
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "86400000" # keep data in primary storage for 1 day
+    "retention.ms"          = "604800000"
+  }
+}`,
+		},
+		{
+			name: "compacted topic with many fixes",
+			input: `
+resource "kafka_topic" "topic_many_fixes" {
+  name               = "topic_many_fixes"
+  replication_factor = 2
+  config = {
+    "remote.storage.enable" = "true"
+    # keep data in primary storage for 1 day
+    "local.retention.ms"    = "86400000"
+    "cleanup.policy"        = "compact, compact"
+    # keep data for 7 days
+    "retention.ms"          = "604800000.0"
+    "compression.type"      = "gzip"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_many_fixes" {
+  name               = "topic_many_fixes"
+  replication_factor = 3
+  config = {
+
+
+    "cleanup.policy" = "compact"
+
+    "compression.type" = "zstd"
+  }
+}`,
+		},
+		{
+			name: "deleted topic with many fixes",
+			input: `
+resource "kafka_topic" "topic_many_fixes" {
+  name = "topic_many_fixes"
+  config = {
+    "cleanup.policy"        = "delete,delete"
+    "remote.storage.enable" = "true"
+    "local.retention.ms"    = "3600000" # keep data in primary storage for 1 hour
+    "retention.ms"          = "86400000.0"
+    "compression.type"      = "gzip"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "topic_many_fixes" {
+  name               = "topic_many_fixes"
+  replication_factor = 3
+  config = {
+    "min.insync.replicas" = "2"
+    "cleanup.policy"      = "delete"
+
+
+    "retention.ms"     = "86400000"
+    "compression.type" = "zstd"
+  }
+}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{fileName: tc.input})
+			require.NoError(t, rule.Check(runner))
+			require.Greater(t, len(runner.Issues), 1, "expected several fixes on the same config")
+
+			fixed := runner.Changes()[fileName]
+			t.Logf("Proposed changes: %s", string(fixed))
+			_, diags := hclsyntax.ParseConfig(fixed, fileName, hcl.InitialPos)
+			require.False(t, diags.HasErrors(), "combined fix is not valid HCL: %s", diags.Error())
+
+			helper.AssertChanges(t, map[string]string{fileName: tc.fixed}, runner.Changes())
+		})
 	}
 }
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// deprecatedTopicAttributesDefault maps the deprecated kafka_topic attributes to their current name.
+var deprecatedTopicAttributesDefault = map[string]string{
+	"config_entries": "config",
+}
+
+type mskTopicDeprecatedAttributesRuleConfig struct {
+	DeprecatedAttributes map[string]string `hclext:"deprecated_attributes,optional"`
+}
+
+// MSKTopicDeprecatedAttributesRule checks whether kafka_topic resources use deprecated provider attributes.
+type MSKTopicDeprecatedAttributesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicDeprecatedAttributesRule) Name() string {
+	return "msk_topic_deprecated_attributes"
+}
+
+func (r *MSKTopicDeprecatedAttributesRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicDeprecatedAttributesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicDeprecatedAttributesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicDeprecatedAttributesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicDeprecatedAttributesRuleConfig{DeprecatedAttributes: deprecatedTopicAttributesDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	logger.Debug("decoded rule config: %v", ruleConfig)
+
+	deprecatedAttrs := slices.Sorted(maps.Keys(ruleConfig.DeprecatedAttributes))
+	attrSchemas := make([]hclext.AttributeSchema, 0, len(deprecatedAttrs))
+	for _, attrName := range deprecatedAttrs {
+		attrSchemas = append(attrSchemas, hclext.AttributeSchema{Name: attrName})
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{Attributes: attrSchemas},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		for _, attrName := range deprecatedAttrs {
+			attr, ok := topicResource.Body.Attributes[attrName]
+			if !ok {
+				continue
+			}
+
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf(
+					"the attribute '%s' is deprecated for kafka_topic: use '%s' instead",
+					attrName,
+					ruleConfig.DeprecatedAttributes[attrName],
+				),
+				attr.Range,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: deprecated attribute: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
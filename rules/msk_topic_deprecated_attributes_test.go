@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicDeprecatedAttributesRule(t *testing.T) {
+	rule := &MSKTopicDeprecatedAttributesRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "deprecated attribute",
+			input: `
+resource "kafka_topic" "topic_with_config_entries" {
+  name = "topic_with_config_entries"
+  config_entries = {
+    "cleanup.policy" = "delete"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "the attribute 'config_entries' is deprecated for kafka_topic: use 'config' instead",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "current attribute",
+			input: `
+resource "kafka_topic" "good_topic" {
+  name = "good_topic"
+  config = {
+    "cleanup.policy" = "delete"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "configured deprecated attribute",
+			config: `
+rule "msk_topic_deprecated_attributes" {
+  enabled = true
+  deprecated_attributes = {
+    "partition_count" = "partitions"
+  }
+}`,
+			input: `
+resource "kafka_topic" "topic_with_partition_count" {
+  name            = "topic_with_partition_count"
+  partition_count = 10
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "the attribute 'partition_count' is deprecated for kafka_topic: use 'partitions' instead",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 23},
+					},
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const topicDocCommentPrefixDefault = "owner:"
+
+type mskTopicDocumentationRuleConfig struct {
+	CommentPrefix string `hclext:"comment_prefix,optional"`
+}
+
+// MSKTopicDocumentationRule checks that each topic is preceded by a comment documenting its owner.
+type MSKTopicDocumentationRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicDocumentationRule) Name() string {
+	return "msk_topic_documentation"
+}
+
+func (r *MSKTopicDocumentationRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicDocumentationRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicDocumentationRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicDocumentationRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicDocumentationRuleConfig{CommentPrefix: topicDocCommentPrefixDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{}, nil)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		comments, err := getCommentsForFile(runner, topicResource.DefRange.Filename)
+		if err != nil {
+			return err
+		}
+		if hasDocComment(comments, topicResource.DefRange.Start.Line, ruleConfig.CommentPrefix) {
+			continue
+		}
+
+		err = runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"topic resource '%s' must be preceded by a comment documenting it, like '# %s ...'",
+				topicResource.Labels[1],
+				ruleConfig.CommentPrefix,
+			),
+			topicResource.DefRange,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: undocumented topic: %w", err)
+		}
+	}
+	return nil
+}
+
+// hasDocComment returns whether one of the comments right above the given line starts with the prefix.
+// The comments are walked up as long as they are contiguous, so the prefix can be on any line of a comment group.
+func hasDocComment(comments hclsyntax.Tokens, line int, prefix string) bool {
+	prevLine := line - 1
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+		if comment.Range.Start.Line > prevLine {
+			continue
+		}
+		if commentLastLine(comment) != prevLine {
+			return false
+		}
+		if strings.HasPrefix(commentText(comment), prefix) {
+			return true
+		}
+		prevLine = comment.Range.Start.Line - 1
+	}
+	return false
+}
+
+// commentLastLine returns the last line with the content of a comment, as line comments include their newline.
+func commentLastLine(comment hclsyntax.Token) int {
+	if comment.Range.End.Column == 1 {
+		return comment.Range.End.Line - 1
+	}
+	return comment.Range.End.Line
+}
+
+// commentText returns the text of a comment, without its delimiters and surrounding spaces.
+func commentText(comment hclsyntax.Token) string {
+	txt := strings.TrimSpace(string(comment.Bytes))
+	for _, delim := range []string{"#", "//", "/*"} {
+		if after, ok := strings.CutPrefix(txt, delim); ok {
+			txt = after
+			break
+		}
+	}
+	return strings.TrimSpace(strings.TrimSuffix(txt, "*/"))
+}
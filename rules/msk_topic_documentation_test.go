@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicDocumentationRule(t *testing.T) {
+	rule := &MSKTopicDocumentationRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "documented topic",
+			input: `
+# owner: billing team
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "documented topic with the owner in a comment group",
+			input: `
+// Contains the orders placed by the customers.
+// owner: billing team
+/* consumed by the invoicing apps */
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "undocumented topic",
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'orders' must be preceded by a comment documenting it, like '# owner: ...'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			name: "comment without the prefix",
+			input: `
+# Contains the orders placed by the customers.
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'orders' must be preceded by a comment documenting it, like '# owner: ...'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			name: "comment not immediately preceding the topic",
+			input: `
+# owner: billing team
+
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'orders' must be preceded by a comment documenting it, like '# owner: ...'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			name: "comment of the previous topic",
+			input: `
+# owner: billing team
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'invoices' must be preceded by a comment documenting it, like '# owner: ...'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			name: "configured prefix",
+			config: `
+rule "msk_topic_documentation" {
+  enabled        = true
+  comment_prefix = "team:"
+}`,
+			input: `
+# team: billing
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+# owner: billing team
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'invoices' must be preceded by a comment documenting it, like '# team: ...'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 34},
+					},
+				},
+			},
+		},
+	})
+}
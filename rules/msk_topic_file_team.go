@@ -0,0 +1,180 @@
+package rules
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicFileTeamRule checks that a file with topics of the module team doesn't define topics of other teams.
+type MSKTopicFileTeamRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicFileTeamRule) Name() string {
+	return "msk_topic_file_team"
+}
+
+func (r *MSKTopicFileTeamRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicFileTeamRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicFileTeamRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+type fileTopic struct {
+	name      string
+	ownerTeam string
+	nameAttr  *hclext.Attribute
+}
+
+func (r *MSKTopicFileTeamRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	// the team aliases are configured on the topic name rule
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: append([]hclext.AttributeSchema{{Name: "name"}}, topicMetaArgsSchema...),
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+
+	var fileNames []string
+	topicsPerFile := map[string][]fileTopic{}
+	for _, topicResource := range resourceContents.Blocks {
+		nameAttr, hasName := topicResource.Body.Attributes["name"]
+		if !hasName {
+			continue
+		}
+
+		var topicName string
+		diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &topicName)
+		if diags.HasErrors() {
+			if isDynamicTopic(topicResource) {
+				logger.Debug("skipping topic defined with for_each or count", "labels", topicResource.Labels)
+				continue
+			}
+			return fmt.Errorf("decoding name for kafka_topic '%s': %w", topicResource.Labels[1], diags)
+		}
+
+		ownerTeam, ok := topicOwnerTeam(topicName, teamName, topicNameConfig.TeamAliases, topicNameConfig.prefixOptions())
+		if !ok {
+			continue
+		}
+
+		fileName := topicResource.DefRange.Filename
+		if _, seen := topicsPerFile[fileName]; !seen {
+			fileNames = append(fileNames, fileName)
+		}
+		topicsPerFile[fileName] = append(
+			topicsPerFile[fileName],
+			fileTopic{name: topicName, ownerTeam: ownerTeam, nameAttr: nameAttr},
+		)
+	}
+
+	for _, fileName := range fileNames {
+		if err := r.validateSingleTeam(runner, fileName, topicsPerFile[fileName], teamName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicFileTeamRule) validateSingleTeam(
+	runner tflint.Runner,
+	fileName string,
+	topics []fileTopic,
+	teamName string,
+) error {
+	hasTeamTopics := slices.ContainsFunc(topics, func(topic fileTopic) bool {
+		return topic.ownerTeam == teamName
+	})
+	if !hasTeamTopics {
+		return nil
+	}
+
+	for _, topic := range topics {
+		if topic.ownerTeam == teamName {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"topic '%s' belongs to team '%s', but '%s' defines the topics of team '%s': a topic file must contain the topics of a single team",
+				topic.name,
+				topic.ownerTeam,
+				fileName,
+				teamName,
+			),
+			topic.nameAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: topic of another team: %w", err)
+		}
+	}
+	return nil
+}
+
+// topicOwnerTeam returns the team owning the topic, given by its prefix which is either the team name or one of its aliases.
+// The prefixes are matched like the msk_topic_name rule does, so the topics it accepts belong to the module team.
+func topicOwnerTeam(
+	topicName string,
+	teamName string,
+	aliases map[string][]string,
+	opts topicPrefixOptions,
+) (string, bool) {
+	prefix, _, found := strings.Cut(topicName, opts.separator)
+	if !found || prefix == "" {
+		return "", false
+	}
+
+	// the module team is matched first, then the other teams in a stable order
+	teams := append([]string{teamName}, slices.Sorted(maps.Keys(aliases))...)
+	for _, team := range teams {
+		if hasTeamNameOrAliasPrefix(topicName, team, aliases[team], opts) {
+			return team, true
+		}
+	}
+	return prefix, true
+}
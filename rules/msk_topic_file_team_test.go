@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicFileTeamRule(t *testing.T) {
+	rule := &MSKTopicFileTeamRule{}
+	workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub")
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "file mixing the topics of multiple teams",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "pubsub_topic" {
+  name = "pubsub.my-topic"
+}
+
+resource "kafka_topic" "iam_topic" {
+  name = "iam.other-topic"
+}`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic 'iam.other-topic' belongs to team 'iam', but 'topics.tf' defines the topics of team 'pubsub': a topic file must contain the topics of a single team",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			name: "topic of another team through its alias",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled = true
+  team_aliases = {
+    iam = ["auth"]
+  }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "pubsub_topic" {
+  name = "pubsub.my-topic"
+}
+
+resource "kafka_topic" "auth_topic" {
+  name = "auth.other-topic"
+}`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic 'auth.other-topic' belongs to team 'iam', but 'topics.tf' defines the topics of team 'pubsub': a topic file must contain the topics of a single team",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			name: "team aliases belong to the module team",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled = true
+  team_aliases = {
+    pubsub = ["alias_pubsub"]
+  }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "pubsub_topic" {
+  name = "pubsub.my-topic"
+}
+
+resource "kafka_topic" "alias_topic" {
+  name = "alias_pubsub.other-topic"
+}`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "case insensitive team prefixes",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled          = true
+  case_insensitive = true
+  team_aliases = {
+    iam = ["auth"]
+  }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "pubsub_topic" {
+  name = "pubsub.my-topic"
+}
+
+resource "kafka_topic" "upper_pubsub_topic" {
+  name = "PubSub.other-topic"
+}
+
+resource "kafka_topic" "auth_topic" {
+  name = "AUTH.auth-topic"
+}`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic 'AUTH.auth-topic' belongs to team 'iam', but 'topics.tf' defines the topics of team 'pubsub': a topic file must contain the topics of a single team",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			name: "topics of other teams in a separate file",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "pubsub_topic" {
+  name = "pubsub.my-topic"
+}`,
+				"iam_topics.tf": `
+resource "kafka_topic" "iam_topic" {
+  name = "iam.other-topic"
+}`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicFiniteRetentionRule checks that the topics which are not compacted have a finite retention time,
+// unless the infinite retention is explicitly accepted by ignoring the rule.
+type MSKTopicFiniteRetentionRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicFiniteRetentionRule) Name() string {
+	return "msk_topic_finite_retention"
+}
+
+func (r *MSKTopicFiniteRetentionRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicFiniteRetentionRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicFiniteRetentionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicFiniteRetentionRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+		// the compacted topics keep the latest value of each key forever, whatever their retention time
+		if isCompactedTopic(topicResource) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		retTimePair, hasRetTime := configKeyToPairMap[retentionTimeAttr]
+		if !hasRetTime {
+			continue
+		}
+		if err := r.validateFiniteRetention(runner, topicResource.Labels[1], retTimePair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicFiniteRetentionRule) validateFiniteRetention(
+	runner tflint.Runner,
+	resourceName string,
+	retTimePair hcl.KeyValuePair,
+) error {
+	retTime, ok, err := decodeIntValue(retTimePair)
+	if err != nil {
+		return err
+	}
+	if !ok || !isInfiniteRetention(retTime) {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"topic resource '%s' is not compacted but keeps its data forever: set a finite %s, "+
+			"or ignore this rule with '# tflint-ignore: %s' when the infinite retention is intended",
+		resourceName,
+		retentionTimeAttr,
+		r.Name(),
+	)
+	if err := runner.EmitIssue(r, msg, retTimePair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: infinite retention on a topic not compacted: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicFiniteRetentionRule(t *testing.T) {
+	rule := &MSKTopicFiniteRetentionRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "delete policy with infinite retention",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "-1"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'topic_def' is not compacted but keeps its data forever: set a finite retention.ms, or ignore this rule with '# tflint-ignore: msk_topic_finite_retention' when the infinite retention is intended",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 24},
+						End:      hcl.Pos{Line: 6, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			name: "default policy with infinite retention",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "-1"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'topic_def' is not compacted but keeps its data forever: set a finite retention.ms, or ignore this rule with '# tflint-ignore: msk_topic_finite_retention' when the infinite retention is intended",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 22},
+						End:      hcl.Pos{Line: 5, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			name: "compact policy with infinite retention",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "compact,delete"
+    "retention.ms"   = "-1"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "delete policy with finite retention",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "cleanup.policy" = "delete"
+    "retention.ms"   = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicJSONSyntaxRule reports topics defined in JSON syntax, as the
+// config and comments rules only support the native HCL syntax and skip them.
+type MSKTopicJSONSyntaxRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicJSONSyntaxRule) Name() string {
+	return "msk_topic_json_syntax"
+}
+
+func (r *MSKTopicJSONSyntaxRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicJSONSyntaxRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicJSONSyntaxRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *MSKTopicJSONSyntaxRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{}, nil)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if !isJSONSyntax(topicResource.DefRange) {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"topic resource '%s' is defined in JSON syntax: the config and comments checks only support the native HCL syntax and are skipped",
+				topicResource.Labels[1],
+			),
+			topicResource.DefRange,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: topic in JSON syntax: %w", err)
+		}
+	}
+
+	return nil
+}
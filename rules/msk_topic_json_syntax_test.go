@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const jsonTopicFileName = "topics.tf.json"
+
+const jsonTopic = `{
+  "resource": {
+    "kafka_topic": {
+      "json_topic": {
+        "name": "pubsub.json-topic",
+        "replication_factor": 3,
+        "config": {
+          "cleanup.policy": "delete",
+          "retention.ms": "86400000"
+        }
+      }
+    }
+  }
+}`
+
+func Test_MSKTopicJSONSyntaxRule(t *testing.T) {
+	rule := &MSKTopicJSONSyntaxRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name:  "topic in JSON syntax",
+			files: map[string]string{jsonTopicFileName: jsonTopic},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic resource 'json_topic' is defined in JSON syntax: the config and comments checks only support the native HCL syntax and are skipped",
+					Range: hcl.Range{
+						Filename: jsonTopicFileName,
+						Start:    hcl.Pos{Line: 4, Column: 21},
+						End:      hcl.Pos{Line: 4, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			name: "topic in native syntax",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "native_topic" {
+  name = "pubsub.native-topic"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
+
+func Test_ConfigRulesSkipJSONSyntax(t *testing.T) {
+	for _, rule := range []tflint.Rule{&MSKTopicConfigRule{}, &MSKTopicConfigCommentsRule{}} {
+		t.Run(rule.Name(), func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{jsonTopicFileName: jsonTopic})
+
+			require.NoError(t, rule.Check(runner))
+
+			assert.Empty(t, runner.Issues)
+			assert.Empty(t, runner.Changes())
+		})
+	}
+}
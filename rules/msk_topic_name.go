@@ -3,16 +3,39 @@ package rules
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 type mskTopicNameRuleConfig struct {
-	TeamAliases map[string][]string `hclext:"team_aliases,optional"`
+	TeamAliases       map[string][]string `hclext:"team_aliases,optional"`
+	TopicResourceType string              `hclext:"topic_resource_type,optional"`
+	Separator         string              `hclext:"separator,optional"`
+	CaseInsensitive   bool                `hclext:"case_insensitive,optional"`
+}
+
+const defaultTopicNameSeparator = "."
+
+// topicPrefixOptions configures how a name is matched against the team prefixes.
+type topicPrefixOptions struct {
+	separator       string
+	caseInsensitive bool
+}
+
+// prefixOptions returns the options matching the topic names against the team prefixes, '.' separated by default.
+func (c mskTopicNameRuleConfig) prefixOptions() topicPrefixOptions {
+	separator := c.Separator
+	if separator == "" {
+		separator = defaultTopicNameSeparator
+	}
+	return topicPrefixOptions{separator: separator, caseInsensitive: c.CaseInsensitive}
 }
 
 // MSKTopicNameRule checks whether a topic defined in MSK has an allowed team prefix.
@@ -54,10 +77,15 @@ func (r *MSKTopicNameRule) Check(runner tflint.Runner) error {
 
 	logger.Debug("decoded rule config: %v", config)
 
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
 	resourceContents, err := runner.GetResourceContent(
-		"kafka_topic",
+		resourceType,
 		&hclext.BodySchema{
-			Attributes: []hclext.AttributeSchema{{Name: "name"}},
+			Attributes: append([]hclext.AttributeSchema{{Name: "name"}}, topicMetaArgsSchema...),
 		},
 		nil,
 	)
@@ -65,14 +93,15 @@ func (r *MSKTopicNameRule) Check(runner tflint.Runner) error {
 		return fmt.Errorf("getting kafka_topic contents: %w", err)
 	}
 
-	modulePath, err := runner.GetOriginalwd()
-	if err != nil {
-		return fmt.Errorf("failed getting module path: %w", err)
+	modulePath, ok := getModulePath(runner)
+	// without the module path, only the prefix check is skipped, as the other checks don't depend on the team
+	var teamName string
+	if ok {
+		teamName = filepath.Base(modulePath)
 	}
-	teamName := filepath.Base(modulePath)
 
 	for _, topicResource := range resourceContents.Blocks {
-		if err := r.validateTopicName(runner, topicResource, teamName, config.TeamAliases); err != nil {
+		if err := r.validateTopicName(runner, topicResource, teamName, config); err != nil {
 			return err
 		}
 	}
@@ -80,17 +109,25 @@ func (r *MSKTopicNameRule) Check(runner tflint.Runner) error {
 	return nil
 }
 
+// maxTopicNameLength is the maximum length of a topic name accepted by the kafka brokers.
+const maxTopicNameLength = 249
+
+// reservedTopicNames are rejected by the kafka brokers, as they would clash with the log directories.
+var reservedTopicNames = []string{".", ".."}
+
 func (r *MSKTopicNameRule) validateTopicName(
 	runner tflint.Runner,
 	topic *hclext.Block,
 	teamName string,
-	aliases map[string][]string,
+	config mskTopicNameRuleConfig,
 ) error {
 	resourceName := topic.Labels[1]
 	nameAttr, hasName := topic.Body.Attributes["name"]
 	if !hasName {
-		err := runner.EmitIssue(
+		err := emitCategorizedIssue(
+			runner,
 			r,
+			categoryNaming,
 			fmt.Sprintf("topic resource '%s' must have the name defined", resourceName),
 			topic.DefRange,
 		)
@@ -103,11 +140,61 @@ func (r *MSKTopicNameRule) validateTopicName(
 	var topicName string
 	diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &topicName)
 	if diags.HasErrors() {
+		if isDynamicTopic(topic) {
+			return r.reportDynamicTopic(runner, resourceName, nameAttr)
+		}
 		return fmt.Errorf("decoding name for kafka_topic '%s': %w", resourceName, diags)
 	}
 
-	teamAliases := aliases[teamName]
-	if hasTeamNameOrAliasPrefix(topicName, teamName, teamAliases) {
+	if slices.Contains(reservedTopicNames, topicName) {
+		err := emitCategorizedIssue(
+			runner,
+			r,
+			categoryNaming,
+			fmt.Sprintf("topic name '%s' is reserved: the brokers reject the topic names '.' and '..'", topicName),
+			nameAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: reserved topic name: %w", err)
+		}
+		return nil
+	}
+
+	if len(topicName) > maxTopicNameLength {
+		err := emitCategorizedIssue(
+			runner,
+			r,
+			categoryNaming,
+			fmt.Sprintf(
+				"topic name must not be longer than %d characters, as the brokers reject longer names. Current length is %d",
+				maxTopicNameLength,
+				len(topicName),
+			),
+			nameAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: topic name too long: %w", err)
+		}
+	}
+
+	if teamName != "" {
+		err := r.validateTopicNamePrefix(runner, nameAttr, topicName, teamName, config.TeamAliases[teamName], config.prefixOptions())
+		if err != nil {
+			return err
+		}
+	}
+	return r.validateTopicNameChars(runner, nameAttr, topicName)
+}
+
+func (r *MSKTopicNameRule) validateTopicNamePrefix(
+	runner tflint.Runner,
+	nameAttr *hclext.Attribute,
+	topicName string,
+	teamName string,
+	teamAliases []string,
+	opts topicPrefixOptions,
+) error {
+	if hasTeamNameOrAliasPrefix(topicName, teamName, teamAliases, opts) {
 		return nil
 	}
 
@@ -123,17 +210,92 @@ func (r *MSKTopicNameRule) validateTopicName(
 		im = fmt.Sprintf("topic name must be prefixed with the team name '%s'. Current value is '%s'", teamName, topicName)
 	}
 
-	err := runner.EmitIssue(r, im, nameAttr.Range)
+	// a name with a prefix of another team is ambiguous: it could be a typo of the prefix, or a topic to move
+	nameExpr, isSyntaxExpr := nameAttr.Expr.(hclsyntax.Expression)
+	if strings.Contains(topicName, opts.separator) || !isSyntaxExpr || !isStringLiteral(nameExpr) {
+		err := emitCategorizedIssue(runner, r, categoryNaming, im, nameAttr.Range)
+		if err != nil {
+			return fmt.Errorf("emitting issue: topic name doesn't have the expected prefix: %w", err)
+		}
+		return nil
+	}
+
+	err := emitCategorizedIssueWithFix(runner, r, categoryNaming, im, nameAttr.Range,
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(nameExpr.Range(), fmt.Sprintf("%q", teamName+opts.separator+topicName))
+		},
+	)
 	if err != nil {
 		return fmt.Errorf("emitting issue: topic name doesn't have the expected prefix: %w", err)
 	}
 	return nil
 }
 
-func hasTeamNameOrAliasPrefix(topicName string, teamName string, aliases []string) bool {
-	aliases = append(aliases, teamName)
-	for _, value := range aliases {
-		if strings.HasPrefix(topicName, value+".") {
+// isLegalTopicNameChar returns whether the character is accepted by the kafka brokers in a topic name: [a-zA-Z0-9._-].
+func isLegalTopicNameChar(c rune) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-'
+}
+
+func (r *MSKTopicNameRule) validateTopicNameChars(
+	runner tflint.Runner,
+	nameAttr *hclext.Attribute,
+	topicName string,
+) error {
+	idx := strings.IndexFunc(topicName, func(c rune) bool { return !isLegalTopicNameChar(c) })
+	if idx < 0 {
+		return nil
+	}
+
+	illegalChar, _ := utf8.DecodeRuneInString(topicName[idx:])
+	err := emitCategorizedIssue(
+		runner,
+		r,
+		categoryNaming,
+		fmt.Sprintf(
+			"topic name must only contain the characters [a-zA-Z0-9._-], but it contains '%c' at position %d. Current value is '%s'",
+			illegalChar,
+			utf8.RuneCountInString(topicName[:idx])+1,
+			topicName,
+		),
+		nameAttr.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: illegal topic name character: %w", err)
+	}
+	return nil
+}
+
+func (r *MSKTopicNameRule) reportDynamicTopic(
+	runner tflint.Runner,
+	resourceName string,
+	nameAttr *hclext.Attribute,
+) error {
+	err := emitCategorizedIssue(
+		runner,
+		withSeverity(r, tflint.NOTICE),
+		categoryNaming,
+		fmt.Sprintf(
+			"topic resource '%s' is defined with for_each or count: its name can't be statically verified",
+			resourceName,
+		),
+		nameAttr.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: dynamic topic name: %w", err)
+	}
+	return nil
+}
+
+func hasTeamNameOrAliasPrefix(topicName string, teamName string, aliases []string, opts topicPrefixOptions) bool {
+	if opts.caseInsensitive {
+		topicName = strings.ToLower(topicName)
+	}
+	for _, value := range append([]string{teamName}, aliases...) {
+		prefix := value + opts.separator
+		if opts.caseInsensitive {
+			prefix = strings.ToLower(prefix)
+		}
+		if strings.HasPrefix(topicName, prefix) {
 			return true
 		}
 	}
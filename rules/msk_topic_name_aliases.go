@@ -0,0 +1,88 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicNameAliasesRule notices the team_aliases of the module team, configured on the msk_topic_name rule,
+// which no topic of the module is prefixed with, usually stale or typos. The aliases of the other teams are
+// ignored, as a shared config defines them for all the teams.
+type MSKTopicNameAliasesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicNameAliasesRule) Name() string {
+	return "msk_topic_name_aliases"
+}
+
+func (r *MSKTopicNameAliasesRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicNameAliasesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+// Severity is a notice, as the issues are about the rule config and can't point at it.
+func (r *MSKTopicNameAliasesRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+func (r *MSKTopicNameAliasesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if len(topicNameConfig.TeamAliases) == 0 {
+		return nil
+	}
+
+	modulePath, ok := getModulePath(runner)
+	if !ok {
+		return nil
+	}
+	teamName := filepath.Base(modulePath)
+	prefixOpts := topicNameConfig.prefixOptions()
+
+	topics, _, err := getKafkaTopicNames(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, alias := range topicNameConfig.TeamAliases[teamName] {
+		if slices.ContainsFunc(topics, func(topic kafkaTopicName) bool {
+			return hasTeamNameOrAliasPrefix(topic.name, alias, nil, prefixOpts)
+		}) {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"team_aliases alias '%s' of the module team '%s' doesn't prefix any topic of the module: check it for typos or remove it",
+				alias,
+				teamName,
+			),
+			hcl.Range{},
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: stale team alias: %w", err)
+		}
+	}
+	return nil
+}
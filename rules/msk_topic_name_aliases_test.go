@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicNameAliasesRule(t *testing.T) {
+	rule := &MSKTopicNameAliasesRule{}
+
+	workDir := filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub")
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "stale alias of the module team",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled      = true
+  team_aliases = { "pubsub" = ["ps", "psub"] }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "ps.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "team_aliases alias 'psub' of the module team 'pubsub' doesn't prefix any topic of the module: check it for typos or remove it",
+					Range:   hcl.Range{},
+				},
+			},
+		},
+		{
+			name: "aliases of the module team used by the topics",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled          = true
+  case_insensitive = true
+  team_aliases     = { "pubsub" = ["ps"] }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "PS.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "unused aliases of other teams",
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled      = true
+  team_aliases = { "pubsubb" = ["ps"], "otel" = ["telemetry"] }
+}`,
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "no team aliases",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := WithWorkDir(helper.TestRunner(t, tc.files), workDir)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Runner.Issues)
+		})
+	}
+}
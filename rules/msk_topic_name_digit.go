@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicNameDigitRule checks that the topic name after the team prefix doesn't start with a digit.
+type MSKTopicNameDigitRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicNameDigitRule) Name() string {
+	return "msk_topic_name_digit"
+}
+
+func (r *MSKTopicNameDigitRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicNameDigitRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicNameDigitRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKTopicNameDigitRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: append([]hclext.AttributeSchema{{Name: "name"}}, topicMetaArgsSchema...),
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		nameAttr, hasName := topicResource.Body.Attributes["name"]
+		if !hasName {
+			continue
+		}
+
+		var topicName string
+		diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &topicName)
+		if diags.HasErrors() {
+			if isDynamicTopic(topicResource) {
+				logger.Debug("skipping topic defined with for_each or count", "labels", topicResource.Labels)
+				continue
+			}
+			return fmt.Errorf("decoding name for kafka_topic '%s': %w", topicResource.Labels[1], diags)
+		}
+
+		_, nameAfterPrefix, hasPrefix := strings.Cut(topicName, ".")
+		if !hasPrefix || nameAfterPrefix == "" || !unicode.IsDigit(rune(nameAfterPrefix[0])) {
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("topic name must not start with a digit after the team prefix. Current value is '%s'", topicName),
+			nameAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: topic name starts with a digit: %w", err)
+		}
+	}
+
+	return nil
+}
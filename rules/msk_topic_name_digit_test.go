@@ -0,0 +1,59 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicNameDigitRule(t *testing.T) {
+	rule := &MSKTopicNameDigitRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "topic name starting with a digit after the prefix",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+	name = "team.1orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic name must not start with a digit after the team prefix. Current value is 'team.1orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			name: "topic name with a digit later",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+	name = "team.orders1"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const topicNameDomainDepthDefault = 2
+
+type mskTopicNameDomainRuleConfig struct {
+	Depth int `hclext:"depth,optional"`
+}
+
+// MSKTopicNameDomainRule checks that all the topics of a module share the same name prefix up to the configured depth,
+// like 'team.system'.
+type MSKTopicNameDomainRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicNameDomainRule) Name() string {
+	return "msk_topic_name_domain"
+}
+
+func (r *MSKTopicNameDomainRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicNameDomainRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicNameDomainRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicNameDomainRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicNameDomainRuleConfig{Depth: topicNameDomainDepthDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if ruleConfig.Depth < 1 {
+		return fmt.Errorf("depth must be a positive integer, got %d", ruleConfig.Depth)
+	}
+
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	prefixOpts := topicNameConfig.prefixOptions()
+
+	topics, _, err := getKafkaTopicNames(runner)
+	if err != nil {
+		return err
+	}
+	if len(topics) < 2 {
+		return nil
+	}
+
+	domains := make([]string, len(topics))
+	for i, topic := range topics {
+		domains[i] = topicNameDomain(topic.name, ruleConfig.Depth, prefixOpts)
+	}
+	commonDomain := mostCommonDomain(domains)
+	if commonDomain == "" {
+		logger.Debug("skipping topic name domains, as no topic has enough segments")
+		return nil
+	}
+
+	for i, topic := range topics {
+		if domains[i] == commonDomain {
+			continue
+		}
+
+		msg := fmt.Sprintf(
+			"topic name '%s' doesn't share the prefix '%s' of the other topics of the module, up to %d segments",
+			topic.name,
+			commonDomain,
+			ruleConfig.Depth,
+		)
+		if domains[i] == "" {
+			msg = fmt.Sprintf(
+				"topic name '%s' must have more than %d '%s' separated segments, sharing the prefix '%s' of the other topics of the module",
+				topic.name,
+				ruleConfig.Depth,
+				prefixOpts.separator,
+				commonDomain,
+			)
+		}
+		if err := runner.EmitIssue(r, msg, topic.attr.Range); err != nil {
+			return fmt.Errorf("emitting issue: topic name outside of the module domain: %w", err)
+		}
+	}
+	return nil
+}
+
+// topicNameDomain returns the first depth segments of the topic name, or an empty string when the name has no segments
+// after them.
+func topicNameDomain(topicName string, depth int, opts topicPrefixOptions) string {
+	segments := strings.Split(topicName, opts.separator)
+	if len(segments) <= depth {
+		return ""
+	}
+
+	domain := strings.Join(segments[:depth], opts.separator)
+	if opts.caseInsensitive {
+		return strings.ToLower(domain)
+	}
+	return domain
+}
+
+// mostCommonDomain returns the non-empty domain shared by most topics, preferring the one reaching the count first on ties.
+func mostCommonDomain(domains []string) string {
+	counts := map[string]int{}
+	var common string
+	for _, domain := range domains {
+		if domain == "" {
+			continue
+		}
+		counts[domain]++
+		if counts[domain] > counts[common] {
+			common = domain
+		}
+	}
+	return common
+}
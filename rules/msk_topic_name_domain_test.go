@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicNameDomainRule(t *testing.T) {
+	rule := &MSKTopicNameDomainRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "topics sharing the domain",
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.billing.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.billing.invoices"
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "topic outside of the domain",
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.billing.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.billing.invoices"
+}
+
+resource "kafka_topic" "users" {
+  name = "pubsub.accounts.users"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic name 'pubsub.accounts.users' doesn't share the prefix 'pubsub.billing' of the other topics of the module, up to 2 segments",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 33},
+					},
+				},
+			},
+		},
+		{
+			name: "topic without enough segments",
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.billing.orders"
+}
+
+resource "kafka_topic" "invoices" {
+  name = "pubsub.invoices"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic name 'pubsub.invoices' must have more than 2 '.' separated segments, sharing the prefix 'pubsub.billing' of the other topics of the module",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			name: "configured depth",
+			config: `
+rule "msk_topic_name_domain" {
+  enabled = true
+  depth   = 1
+}`,
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.billing.orders"
+}
+
+resource "kafka_topic" "users" {
+  name = "pubsub.accounts.users"
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "single topic",
+			input: `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -2,11 +2,14 @@ package rules
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_MSKTopics(t *testing.T) {
@@ -17,6 +20,7 @@ func Test_MSKTopics(t *testing.T) {
 		files    map[string]string
 		workDir  string
 		expected helper.Issues
+		fixed    string
 	}{
 		{
 			name:    "topic doesn't have a name",
@@ -30,7 +34,7 @@ resource "kafka_topic" "topic_without_name" {
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
-					Message: "topic resource 'topic_without_name' must have the name defined",
+					Message: "[naming] topic resource 'topic_without_name' must have the name defined",
 					Range: hcl.Range{
 						Filename: "topics.tf",
 						Start:    hcl.Pos{Line: 2, Column: 1},
@@ -52,7 +56,7 @@ resource "kafka_topic" "wrong_topic" {
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
-					Message: "topic name must be prefixed with the team name 'pubsub'. Current value is 'name-without-prefix'",
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub'. Current value is 'name-without-prefix'",
 					Range: hcl.Range{
 						Filename: "topics.tf",
 						Start:    hcl.Pos{Line: 3, Column: 2},
@@ -60,6 +64,33 @@ resource "kafka_topic" "wrong_topic" {
 					},
 				},
 			},
+			fixed: `
+resource "kafka_topic" "wrong_topic" {
+  name = "pubsub.name-without-prefix"
+}
+`,
+		},
+		{
+			name:    "topic has the prefix of another team",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "wrong_topic" {
+	name = "other-team.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub'. Current value is 'other-team.orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+			},
 		},
 		{
 			name:    "topic doesn't have alias as prefix",
@@ -82,7 +113,7 @@ resource "kafka_topic" "wrong_topic" {
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
-					Message: "topic name must be prefixed with the team name 'pubsub' or one of its aliases 'alias_pubsub1, alias_pubsub2'. Current value is 'name-without-prefix'",
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub' or one of its aliases 'alias_pubsub1, alias_pubsub2'. Current value is 'name-without-prefix'",
 					Range: hcl.Range{
 						Filename: "topics.tf",
 						Start:    hcl.Pos{Line: 3, Column: 2},
@@ -90,6 +121,11 @@ resource "kafka_topic" "wrong_topic" {
 					},
 				},
 			},
+			fixed: `
+resource "kafka_topic" "wrong_topic" {
+  name = "pubsub.name-without-prefix"
+}
+`,
 		},
 		{
 			name:    "good topic with prefix as alias from config",
@@ -125,6 +161,235 @@ resource "kafka_topic" "good_topic" {
 			},
 			expected: []*helper.Issue{},
 		},
+		{
+			name:    "topic name longer than the broker limit",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "long_topic" {
+	name = "pubsub.` + strings.Repeat("a", 243) + `"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must not be longer than 249 characters, as the brokers reject longer names. Current length is 250",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 261},
+					},
+				},
+			},
+		},
+		{
+			name:    "topic name at the broker limit",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "long_topic" {
+	name = "pubsub.` + strings.Repeat("a", 242) + `"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name:    "reserved topic names",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "dot" {
+	name = "."
+}
+
+resource "kafka_topic" "dot_dot" {
+	name = ".."
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name '.' is reserved: the brokers reject the topic names '.' and '..'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 12},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "[naming] topic name '..' is reserved: the brokers reject the topic names '.' and '..'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 13},
+					},
+				},
+			},
+		},
+		{
+			name:    "topic name with a space",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "spaced_topic" {
+	name = "pubsub.my topic"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must only contain the characters [a-zA-Z0-9._-], but it contains ' ' at position 10. Current value is 'pubsub.my topic'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			name:    "topic name with a slash and a wrong prefix",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "slashed_topic" {
+	name = "other/topic"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub'. Current value is 'other/topic'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must only contain the characters [a-zA-Z0-9._-], but it contains '/' at position 6. Current value is 'other/topic'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 22},
+					},
+				},
+			},
+			fixed: `
+resource "kafka_topic" "slashed_topic" {
+  name = "pubsub.other/topic"
+}
+`,
+		},
+		{
+			name:    "topic prefix matched case-insensitively",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled          = true
+  case_insensitive = true
+}`,
+				"topics.tf": `
+resource "kafka_topic" "good_topic" {
+	name = "Pubsub.good-topic"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name:    "topic prefix matched case-sensitively by default",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "wrong_topic" {
+	name = "Pubsub.good-topic"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub'. Current value is 'Pubsub.good-topic'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			name:    "topic prefix with an underscore separator",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				".tflint.hcl": `
+rule "msk_topic_name" {
+  enabled   = true
+  separator = "_"
+}`,
+				"topics.tf": `
+resource "kafka_topic" "good_topic" {
+	name = "pubsub_good-topic"
+}
+
+resource "kafka_topic" "no_prefix_topic" {
+	name = "orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "[naming] topic name must be prefixed with the team name 'pubsub'. Current value is 'orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 2},
+						End:      hcl.Pos{Line: 7, Column: 17},
+					},
+				},
+			},
+			fixed: `
+resource "kafka_topic" "good_topic" {
+  name = "pubsub_good-topic"
+}
+
+resource "kafka_topic" "no_prefix_topic" {
+  name = "pubsub_orders"
+}
+`,
+		},
+		{
+			name:    "topic defined with for_each",
+			workDir: filepath.Join("kafka-cluster-config", "dev-aws", "kafka-shared-msk", "pubsub"),
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "topics" {
+	for_each = toset(["pubsub.first", "pubsub.second"])
+	name     = each.value
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.NOTICE),
+					Message: "[naming] topic resource 'topics' is defined with for_each or count: its name can't be statically verified",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 4, Column: 2},
+						End:      hcl.Pos{Line: 4, Column: 23},
+					},
+				},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			runner := WithWorkDir(helper.TestRunner(t, tc.files), tc.workDir)
@@ -132,6 +397,79 @@ resource "kafka_topic" "good_topic" {
 			require.NoError(t, rule.Check(runner))
 
 			helper.AssertIssues(t, tc.expected, runner.Issues)
+			if tc.fixed != "" {
+				helper.AssertChanges(t, map[string]string{"topics.tf": tc.fixed}, runner.Changes())
+			} else {
+				assert.Empty(t, runner.Changes())
+			}
 		})
 	}
 }
+
+func Test_MSKTopicsWithoutWorkDir(t *testing.T) {
+	rule := &MSKTopicNameRule{}
+
+	runner := WithoutWorkDir(helper.TestRunner(t, map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "good_topic" {
+	name = "pubsub.good-topic"
+}
+`,
+	}))
+
+	require.NoError(t, rule.Check(runner))
+
+	assert.Empty(t, runner.Issues, "the module path is only noticed by msk_module_backend")
+}
+
+func Test_MSKTopicsWithoutWorkDirReportsNameLength(t *testing.T) {
+	rule := &MSKTopicNameRule{}
+
+	runner := WithoutWorkDir(helper.TestRunner(t, map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "long_topic" {
+  name = "pubsub.` + strings.Repeat("a", 250) + `"
+}
+`,
+	}))
+
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[naming] topic name must not be longer than 249 characters, as the brokers reject longer names. Current length is 257",
+			Range: hcl.Range{
+				Filename: "topics.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 269},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_MSKTopicsWithoutWorkDirReportsIllegalChars(t *testing.T) {
+	rule := &MSKTopicNameRule{}
+
+	runner := WithoutWorkDir(helper.TestRunner(t, map[string]string{
+		"topics.tf": `
+resource "kafka_topic" "my_topic" {
+  name = "pubsub.my topic"
+}
+`,
+	}))
+
+	require.NoError(t, rule.Check(runner))
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    rule,
+			Message: "[naming] topic name must only contain the characters [a-zA-Z0-9._-], but it contains ' ' at position 10. Current value is 'pubsub.my topic'",
+			Range: hcl.Range{
+				Filename: "topics.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 27},
+			},
+		},
+	}, runner.Issues)
+}
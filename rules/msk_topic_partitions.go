@@ -0,0 +1,220 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+const (
+	partitionsAttrName   = "partitions"
+	minPartitionsDefault = 1
+	maxPartitionsDefault = 50
+)
+
+type mskTopicPartitionsRuleConfig struct {
+	MinPartitions                int  `hclext:"min_partitions,optional"`
+	MaxPartitions                int  `hclext:"max_partitions,optional"`
+	WarnSinglePartitionCompacted bool `hclext:"warn_single_partition_compacted,optional"`
+}
+
+// MSKTopicPartitionsRule checks that the partitions of a topic are defined and within the configured range.
+type MSKTopicPartitionsRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicPartitionsRule) Name() string {
+	return "msk_topic_partitions"
+}
+
+func (r *MSKTopicPartitionsRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicPartitionsRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicPartitionsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKTopicPartitionsRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicPartitionsRuleConfig{
+		MinPartitions: minPartitionsDefault,
+		MaxPartitions: maxPartitionsDefault,
+	}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if err := validatePartitionsRange(ruleConfig); err != nil {
+		return err
+	}
+	logger.Debug("decoded rule config: %v", ruleConfig)
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: partitionsAttrName}, {Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		partitionsAttr, hasPartitions := topicResource.Body.Attributes[partitionsAttrName]
+		if !hasPartitions {
+			err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("topic resource '%s' must have the partitions defined", topicResource.Labels[1]),
+				topicResource.DefRange,
+			)
+			if err != nil {
+				return fmt.Errorf("emitting issue: missing partitions: %w", err)
+			}
+			continue
+		}
+		if err := r.validatePartitions(runner, ruleConfig, topicResource, partitionsAttr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicPartitionsRule) validatePartitions(
+	runner tflint.Runner,
+	ruleConfig mskTopicPartitionsRuleConfig,
+	topic *hclext.Block,
+	partitionsAttr *hclext.Attribute,
+) error {
+	val, diags := partitionsAttr.Expr.Value(nil)
+	if diags.HasErrors() {
+		logger.Debug("skipping partitions which are not a static value", "labels", topic.Labels, "diags", diags.Error())
+		return nil
+	}
+
+	var partitions int
+	if err := gocty.FromCtyValue(val, &partitions); err != nil {
+		err := runner.EmitIssue(
+			r,
+			"partitions must be a positive integer, as the provider rejects other values",
+			partitionsAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: partitions not an integer: %w", err)
+		}
+		return nil
+	}
+
+	if partitions <= 0 {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("partitions must be a positive integer, as the provider rejects other values. Current value is '%d'", partitions),
+			partitionsAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: partitions not positive: %w", err)
+		}
+		return nil
+	}
+
+	if partitions < ruleConfig.MinPartitions {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("partitions must be at least %d. Current value is '%d'", ruleConfig.MinPartitions, partitions),
+			partitionsAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: partitions below minimum: %w", err)
+		}
+		return nil
+	}
+
+	if partitions > ruleConfig.MaxPartitions {
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"partitions must be at most %d, as the partitions of a topic can't be decreased once created. Current value is '%d'",
+				ruleConfig.MaxPartitions,
+				partitions,
+			),
+			partitionsAttr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: partitions above maximum: %w", err)
+		}
+		return nil
+	}
+
+	if partitions > 1 || !ruleConfig.WarnSinglePartitionCompacted || !isCompactedTopic(topic) {
+		return nil
+	}
+	err := runner.EmitIssue(
+		withSeverity(r, tflint.WARNING),
+		"compacted topic has a single partition, which serializes all its consumers: use more partitions to consume the keys in parallel",
+		partitionsAttr.Range,
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: compacted topic with a single partition: %w", err)
+	}
+	return nil
+}
+
+// isCompactedTopic returns whether the cleanup policy of the topic includes compaction.
+// Topics whose config can't be statically decoded are considered not compacted.
+func isCompactedTopic(topic *hclext.Block) bool {
+	configAttr, hasConfig := topic.Body.Attributes["config"]
+	if !hasConfig || isJSONSyntax(topic.DefRange) {
+		return false
+	}
+
+	configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+	if err != nil {
+		logger.Debug("skipping topic config which is not a static object", "labels", topic.Labels, "error", err)
+		return false
+	}
+	cpPair, hasCp := configKeyToPairMap[cleanupPolicyKey]
+	if !hasCp {
+		return false
+	}
+	var cpVal string
+	if diags := gohcl.DecodeExpression(cpPair.Value, nil, &cpVal); diags.HasErrors() {
+		return false
+	}
+	return slices.Contains(cleanupPolicyTokens(cpVal), cleanupPolicyCompact)
+}
+
+func validatePartitionsRange(ruleConfig mskTopicPartitionsRuleConfig) error {
+	if ruleConfig.MinPartitions < 1 {
+		return fmt.Errorf("min_partitions must be a positive integer, got %d", ruleConfig.MinPartitions)
+	}
+	if ruleConfig.MaxPartitions < ruleConfig.MinPartitions {
+		return fmt.Errorf(
+			"max_partitions %d must not be lower than min_partitions %d",
+			ruleConfig.MaxPartitions,
+			ruleConfig.MinPartitions,
+		)
+	}
+	return nil
+}
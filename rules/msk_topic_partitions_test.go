@@ -0,0 +1,313 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_MSKTopicPartitionsRule(t *testing.T) {
+	rule := &MSKTopicPartitionsRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "zero partitions",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 0
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be a positive integer, as the provider rejects other values. Current value is '0'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			name: "negative partitions",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = -3
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be a positive integer, as the provider rejects other values. Current value is '-3'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			name: "fractional partitions",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1.5
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be a positive integer, as the provider rejects other values",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 19},
+					},
+				},
+			},
+		},
+		{
+			name: "positive partitions",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 6
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "missing partitions",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic resource 'topic_def' must have the partitions defined",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			name: "partitions above the default maximum",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1000
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be at most 50, as the partitions of a topic can't be decreased once created. Current value is '1000'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			name: "partitions at the default maximum",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 50
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "partitions below the configured minimum",
+			config: `
+rule "msk_topic_partitions" {
+  enabled        = true
+  min_partitions = 3
+  max_partitions = 12
+}`,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 2
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be at least 3. Current value is '2'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			name: "partitions above the configured maximum",
+			config: `
+rule "msk_topic_partitions" {
+  enabled        = true
+  min_partitions = 3
+  max_partitions = 12
+}`,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 24
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "partitions must be at most 12, as the partitions of a topic can't be decreased once created. Current value is '24'",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			name: "partitions within the configured range",
+			config: `
+rule "msk_topic_partitions" {
+  enabled        = true
+  min_partitions = 3
+  max_partitions = 12
+}`,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 12
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "partitions from a variable",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = var.partitions
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
+
+func Test_MSKTopicPartitionsRuleInvalidConfig(t *testing.T) {
+	rule := &MSKTopicPartitionsRule{}
+
+	runner := helper.TestRunner(t, map[string]string{
+		".tflint.hcl": `
+rule "msk_topic_partitions" {
+  enabled        = true
+  min_partitions = 10
+  max_partitions = 5
+}`,
+		fileName: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 6
+}`,
+	})
+
+	require.EqualError(t, rule.Check(runner), "max_partitions 5 must not be lower than min_partitions 10")
+}
+
+func Test_MSKTopicPartitionsRuleCompactedTopics(t *testing.T) {
+	rule := &MSKTopicPartitionsRule{}
+
+	config := `
+rule "msk_topic_partitions" {
+  enabled                         = true
+  warn_single_partition_compacted = true
+}`
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name:   "compacted topic with a single partition",
+			config: config,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1
+  config = {
+    "cleanup.policy" = "compact"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: "compacted topic has a single partition, which serializes all its consumers: use more partitions to consume the keys in parallel",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			name:   "compacted and deleted topic with spaces in the policy and a single partition",
+			config: config,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1
+  config = {
+    "cleanup.policy" = "delete, compact"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Rule:    withSeverity(rule, tflint.WARNING),
+					Message: "compacted topic has a single partition, which serializes all its consumers: use more partitions to consume the keys in parallel",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			name:   "compacted topic with several partitions",
+			config: config,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 6
+  config = {
+    "cleanup.policy" = "compact"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name:   "deleted topic with a single partition",
+			config: config,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1
+  config = {
+    "cleanup.policy" = "delete"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "compacted topic with a single partition and the check disabled",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 1
+  config = {
+    "cleanup.policy" = "compact"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+const maxTopicBytesDefault = 1024 * bytesInOneGiB
+
+type mskTopicRetentionBytesBudgetRuleConfig struct {
+	MaxTopicBytes int `hclext:"max_topic_bytes,optional"`
+}
+
+// MSKTopicRetentionBytesBudgetRule checks that the data a topic can retain on all its partitions,
+// limited by 'retention.bytes' on each partition, stays within a disk budget.
+type MSKTopicRetentionBytesBudgetRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) Name() string {
+	return "msk_topic_retention_bytes_budget"
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicRetentionBytesBudgetRuleConfig{MaxTopicBytes: maxTopicBytesDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	if ruleConfig.MaxTopicBytes < 1 {
+		return fmt.Errorf("max_topic_bytes must be a positive integer, got %d", ruleConfig.MaxTopicBytes)
+	}
+
+	byteUnits, err := getByteUnitStyle(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: partitionsAttrName}, {Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		partitionsAttr, hasPartitions := topicResource.Body.Attributes[partitionsAttrName]
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasPartitions || !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		var partitions int
+		val, diags := partitionsAttr.Expr.Value(nil)
+		if diags.HasErrors() || gocty.FromCtyValue(val, &partitions) != nil || partitions <= 0 {
+			// the invalid partitions are reported by the msk_topic_partitions rule
+			logger.Debug("skipping partitions which are not a static positive integer", "labels", topicResource.Labels)
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		retBytesPair, hasRetBytes := configKeyToPairMap[retentionBytesAttr]
+		if !hasRetBytes {
+			continue
+		}
+		if err := r.validateRetentionBytesBudget(runner, retBytesPair, partitions, ruleConfig.MaxTopicBytes, byteUnits); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicRetentionBytesBudgetRule) validateRetentionBytesBudget(
+	runner tflint.Runner,
+	retBytesPair hcl.KeyValuePair,
+	partitions int,
+	maxTopicBytes int,
+	byteUnits byteUnitStyle,
+) error {
+	retBytes, ok, err := decodeIntValue(retBytesPair)
+	if err != nil {
+		return err
+	}
+	if !ok || isInfiniteRetention(retBytes) {
+		return nil
+	}
+
+	topicBytes := retBytes * partitions
+	if topicBytes <= maxTopicBytes {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"%s '%d' (%s) on each of the %d partitions retains up to %s, exceeding the topic budget of %s: "+
+			"lower %s or the partitions",
+		retentionBytesAttr,
+		retBytes,
+		formatBytes(retBytes, byteUnits),
+		partitions,
+		formatBytes(topicBytes, byteUnits),
+		formatBytes(maxTopicBytes, byteUnits),
+		retentionBytesAttr,
+	)
+	if err := runner.EmitIssue(r, msg, retBytesPair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: retention bytes above the topic budget: %w", err)
+	}
+	return nil
+}
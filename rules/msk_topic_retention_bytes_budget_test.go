@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicRetentionBytesBudgetRule(t *testing.T) {
+	rule := &MSKTopicRetentionBytesBudgetRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "retention bytes above the default budget",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 12
+  config = {
+    "retention.bytes" = "107374182400"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "retention.bytes '107374182400' (100GiB) on each of the 12 partitions retains up to 1200GiB, exceeding the topic budget of 1024GiB: lower retention.bytes or the partitions",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 25},
+						End:      hcl.Pos{Line: 6, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			name: "retention bytes below the default budget",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 6
+  config = {
+    "retention.bytes" = "107374182400"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "retention bytes above the configured budget",
+			config: `
+rule "msk_topic_retention_bytes_budget" {
+  enabled         = true
+  max_topic_bytes = 10737418240
+}`,
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 3
+  config = {
+    "retention.bytes" = "5368709120"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "retention.bytes '5368709120' (5GiB) on each of the 3 partitions retains up to 15GiB, exceeding the topic budget of 10GiB: lower retention.bytes or the partitions",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 6, Column: 25},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			name: "infinite retention bytes",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = 50
+  config = {
+    "retention.bytes" = "-1"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "partitions from a variable",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name       = "topic_def"
+  partitions = var.partitions
+  config = {
+    "retention.bytes" = "107374182400"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
+
+func Test_MSKTopicRetentionBytesBudgetRuleInvalidBudget(t *testing.T) {
+	tc := topicConfigTestCase{
+		config: `
+rule "msk_topic_retention_bytes_budget" {
+  enabled         = true
+  max_topic_bytes = 0
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+	}
+
+	runner := helper.TestRunner(t, tc.files())
+	require.EqualError(
+		t,
+		(&MSKTopicRetentionBytesBudgetRule{}).Check(runner),
+		"max_topic_bytes must be a positive integer, got 0",
+	)
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const segmentTimeAttr = "segment.ms"
+
+// MSKTopicRetentionSegmentRule checks that the retention time of a topic is not below its segment time.
+type MSKTopicRetentionSegmentRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicRetentionSegmentRule) Name() string {
+	return "msk_topic_retention_segment"
+}
+
+func (r *MSKTopicRetentionSegmentRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicRetentionSegmentRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicRetentionSegmentRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicRetentionSegmentRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		if err := r.validateRetentionNotBelowSegment(runner, configKeyToPairMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicRetentionSegmentRule) validateRetentionNotBelowSegment(
+	runner tflint.Runner,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+) error {
+	retTimePair, hasRetTime := configKeyToPairMap[retentionTimeAttr]
+	segmentTimePair, hasSegmentTime := configKeyToPairMap[segmentTimeAttr]
+	if !hasRetTime || !hasSegmentTime {
+		return nil
+	}
+
+	retTime, ok, err := decodeIntValue(retTimePair)
+	if err != nil {
+		return err
+	}
+	if !ok || isInfiniteRetention(retTime) {
+		return nil
+	}
+
+	segmentTime, ok, err := decodeIntValue(segmentTimePair)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	if retTime >= segmentTime {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"%s '%d' is below %s '%d': the active segment can't be deleted, so the data is kept longer than the retention time",
+		retentionTimeAttr,
+		retTime,
+		segmentTimeAttr,
+		segmentTime,
+	)
+	if err := runner.EmitIssue(r, msg, retTimePair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: retention time below segment time: %w", err)
+	}
+	return nil
+}
+
+// decodeIntValue decodes the integer value of a config pair, returning false when it isn't a valid integer
+// or can't be statically analyzed.
+func decodeIntValue(pair hcl.KeyValuePair) (int, bool, error) {
+	val, ok, err := decodeConfigValue(pair)
+	if err != nil || !ok {
+		return 0, false, err
+	}
+
+	intVal, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, nil
+	}
+	return intVal, true, nil
+}
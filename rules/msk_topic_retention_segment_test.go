@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicRetentionSegmentRule(t *testing.T) {
+	rule := &MSKTopicRetentionSegmentRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "retention time below segment time",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "86400000"
+    "segment.ms"   = "604800000"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "retention.ms '86400000' is below segment.ms '604800000': the active segment can't be deleted, so the data is kept longer than the retention time",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 22},
+						End:      hcl.Pos{Line: 5, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			name: "retention time above segment time",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "604800000"
+    "segment.ms"   = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "infinite retention time",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "-1"
+    "segment.ms"   = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "segment time not defined",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+  config = {
+    "retention.ms" = "86400000"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const retentionBytesAttr = "retention.bytes"
+
+// MSKTopicTieredRetentionBytesRule checks that a topic with tiered storage enabled doesn't limit its retention by size.
+type MSKTopicTieredRetentionBytesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) Name() string {
+	return "msk_topic_tiered_retention_bytes"
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		if err := r.validateRetentionBytes(runner, configKeyToPairMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MSKTopicTieredRetentionBytesRule) validateRetentionBytes(
+	runner tflint.Runner,
+	configKeyToPairMap map[string]hcl.KeyValuePair,
+) error {
+	retBytesPair, hasRetBytes := configKeyToPairMap[retentionBytesAttr]
+	if !hasRetBytes {
+		return nil
+	}
+
+	isTieredDeleteTopic, err := hasTieredStorageWithDeletePolicy(configKeyToPairMap)
+	if err != nil || !isTieredDeleteTopic {
+		return err
+	}
+
+	retBytes, ok, err := decodeIntValue(retBytesPair)
+	if err != nil {
+		return err
+	}
+	if !ok || isInfiniteRetention(retBytes) {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"%s '%d' limits the retention by size while tiered storage is enabled: "+
+			"size-based retention interacts poorly with remote tiering, set it to '-1' and rely on %s",
+		retentionBytesAttr,
+		retBytes,
+		retentionTimeAttr,
+	)
+	if err := runner.EmitIssue(r, msg, retBytesPair.Value.Range()); err != nil {
+		return fmt.Errorf("emitting issue: finite retention bytes with tiered storage: %w", err)
+	}
+	return nil
+}
+
+// hasTieredStorageWithDeletePolicy returns whether the topic has tiered storage enabled and the delete cleanup policy,
+// which is the default one.
+func hasTieredStorageWithDeletePolicy(configKeyToPairMap map[string]hcl.KeyValuePair) (bool, error) {
+	tieredStoragePair, hasTieredStorage := configKeyToPairMap[tieredStorageEnableAttr]
+	if !hasTieredStorage {
+		return false, nil
+	}
+	tieredStorageVal, ok, err := decodeConfigValue(tieredStoragePair)
+	if err != nil || !ok || tieredStorageVal != tieredStorageEnabledValue {
+		return false, err
+	}
+
+	cpPair, hasCp := configKeyToPairMap[cleanupPolicyKey]
+	if !hasCp {
+		return true, nil
+	}
+	cpVal, ok, err := decodeConfigValue(cpPair)
+	if err != nil || !ok {
+		return false, err
+	}
+	return cpVal == cleanupPolicyDelete, nil
+}
@@ -0,0 +1,66 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicTieredRetentionBytesRule(t *testing.T) {
+	rule := &MSKTopicTieredRetentionBytesRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "finite retention bytes with tiered storage",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "retention.bytes"       = "1073741824"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "retention.bytes '1073741824' limits the retention by size while tiered storage is enabled: " +
+						"size-based retention interacts poorly with remote tiering, set it to '-1' and rely on retention.ms",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 8, Column: 31},
+						End:      hcl.Pos{Line: 8, Column: 43},
+					},
+				},
+			},
+		},
+		{
+			name: "infinite retention bytes with tiered storage",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "remote.storage.enable" = "true"
+    "cleanup.policy"        = "delete"
+    "retention.ms"          = "2592000000"
+    "retention.bytes"       = "-1"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "finite retention bytes without tiered storage",
+			input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic-def"
+  config = {
+    "cleanup.policy"  = "delete"
+    "retention.ms"    = "86400000"
+    "retention.bytes" = "1073741824"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+const (
+	timestampTypeKey = "message.timestamp.type"
+	logAppendTime    = "LogAppendTime"
+	createTime       = "CreateTime"
+
+	eventTopicNamePatternDefault = "event"
+)
+
+type mskTopicTimestampTypeRuleConfig struct {
+	TopicNamePattern string `hclext:"topic_name_pattern,optional"`
+}
+
+// MSKTopicTimestampTypeRule checks that the event-sourced topics keep the time of the events set by the producers,
+// instead of the time they are appended to the log.
+type MSKTopicTimestampTypeRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicTimestampTypeRule) Name() string {
+	return "msk_topic_timestamp_type"
+}
+
+func (r *MSKTopicTimestampTypeRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicTimestampTypeRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicTimestampTypeRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicTimestampTypeRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	ruleConfig := mskTopicTimestampTypeRuleConfig{TopicNamePattern: eventTopicNamePatternDefault}
+	if err := runner.DecodeRuleConfig(r.Name(), &ruleConfig); err != nil {
+		return fmt.Errorf("decoding rule config: %w", err)
+	}
+	namePattern, err := regexp.Compile(ruleConfig.TopicNamePattern)
+	if err != nil {
+		return fmt.Errorf("topic_name_pattern must be a valid regular expression: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceContents, err := runner.GetResourceContent(
+		resourceType,
+		&hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "name"}, {Name: "config"}},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting kafka_topic contents: %w", err)
+	}
+
+	for _, topicResource := range resourceContents.Blocks {
+		if isJSONSyntax(topicResource.DefRange) {
+			logger.Debug("skipping topic in JSON syntax", "labels", topicResource.Labels)
+			continue
+		}
+
+		nameAttr, hasName := topicResource.Body.Attributes["name"]
+		configAttr, hasConfig := topicResource.Body.Attributes["config"]
+		if !hasName || !hasConfig || skipNonObjectConfig(topicResource, configAttr) {
+			continue
+		}
+
+		var topicName string
+		diags := gohcl.DecodeExpression(nameAttr.Expr, nil, &topicName)
+		if diags.HasErrors() {
+			logger.Debug("skipping topic with a name which is not a static string", "labels", topicResource.Labels)
+			continue
+		}
+		if !namePattern.MatchString(topicName) {
+			continue
+		}
+
+		configKeyToPairMap, err := constructConfigKeyToPairMap(configAttr)
+		if err != nil {
+			return err
+		}
+		tsPair, hasTs := configKeyToPairMap[timestampTypeKey]
+		if !hasTs {
+			continue
+		}
+		if err := r.validateTimestampType(runner, topicName, ruleConfig.TopicNamePattern, tsPair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MSKTopicTimestampTypeRule) validateTimestampType(
+	runner tflint.Runner,
+	topicName string,
+	namePattern string,
+	tsPair hcl.KeyValuePair,
+) error {
+	var tsVal string
+	diags := gohcl.DecodeExpression(tsPair.Value, nil, &tsVal)
+	if diags.HasErrors() || tsVal != logAppendTime {
+		return nil
+	}
+
+	msg := fmt.Sprintf(
+		"topic '%s' matches the event-sourced topics pattern '%s', so its %s must not be '%s', which loses the original event time: setting it to '%s'...",
+		topicName,
+		namePattern,
+		timestampTypeKey,
+		logAppendTime,
+		createTime,
+	)
+	valueRange := tsPair.Value.Range()
+	err := runner.EmitIssueWithFix(r, msg, valueRange,
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(valueRange, fmt.Sprintf("%q", createTime))
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("emitting issue: log append time on event-sourced topic: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicTimestampTypeRule(t *testing.T) {
+	rule := &MSKTopicTimestampTypeRule{}
+
+	runTopicConfigTests(t, rule, []topicConfigTestCase{
+		{
+			name: "event-sourced topic with log append time",
+			input: `
+resource "kafka_topic" "order_events" {
+  name = "pubsub.order-events"
+  config = {
+    "message.timestamp.type" = "LogAppendTime"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "order_events" {
+  name = "pubsub.order-events"
+  config = {
+    "message.timestamp.type" = "CreateTime"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic 'pubsub.order-events' matches the event-sourced topics pattern 'event', so its message.timestamp.type must not be 'LogAppendTime', which loses the original event time: setting it to 'CreateTime'...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 32},
+						End:      hcl.Pos{Line: 5, Column: 47},
+					},
+				},
+			},
+		},
+		{
+			name: "event-sourced topic with create time",
+			input: `
+resource "kafka_topic" "order_events" {
+  name = "pubsub.order-events"
+  config = {
+    "message.timestamp.type" = "CreateTime"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "other topic with log append time",
+			input: `
+resource "kafka_topic" "audit_log" {
+  name = "pubsub.audit-log"
+  config = {
+    "message.timestamp.type" = "LogAppendTime"
+  }
+}`,
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "topic matching the configured pattern with log append time",
+			config: `
+rule "msk_topic_timestamp_type" {
+  enabled            = true
+  topic_name_pattern = "^pubsub\\.ledger\\."
+}`,
+			input: `
+resource "kafka_topic" "ledger_entries" {
+  name = "pubsub.ledger.entries"
+  config = {
+    "message.timestamp.type" = "LogAppendTime"
+  }
+}
+
+resource "kafka_topic" "order_events" {
+  name = "pubsub.order-events"
+  config = {
+    "message.timestamp.type" = "LogAppendTime"
+  }
+}`,
+			fixed: `
+resource "kafka_topic" "ledger_entries" {
+  name = "pubsub.ledger.entries"
+  config = {
+    "message.timestamp.type" = "CreateTime"
+  }
+}
+
+resource "kafka_topic" "order_events" {
+  name = "pubsub.order-events"
+  config = {
+    "message.timestamp.type" = "LogAppendTime"
+  }
+}`,
+			expected: []*helper.Issue{
+				{
+					Message: "topic 'pubsub.ledger.entries' matches the event-sourced topics pattern '^pubsub\\.ledger\\.', so its message.timestamp.type must not be 'LogAppendTime', which loses the original event time: setting it to 'CreateTime'...",
+					Range: hcl.Range{
+						Filename: fileName,
+						Start:    hcl.Pos{Line: 5, Column: 32},
+						End:      hcl.Pos{Line: 5, Column: 47},
+					},
+				},
+			},
+		},
+	})
+}
+
+func Test_MSKTopicTimestampTypeRuleInvalidPattern(t *testing.T) {
+	tc := topicConfigTestCase{
+		config: `
+rule "msk_topic_timestamp_type" {
+  enabled            = true
+  topic_name_pattern = "events("
+}`,
+		input: `
+resource "kafka_topic" "topic_def" {
+  name = "topic_def"
+}`,
+	}
+
+	runner := helper.TestRunner(t, tc.files())
+	require.EqualError(
+		t,
+		(&MSKTopicTimestampTypeRule{}).Check(runner),
+		"topic_name_pattern must be a valid regular expression: error parsing regexp: missing closing ): `events(`",
+	)
+}
@@ -0,0 +1,81 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// MSKTopicUniqueNamesRule checks that the kafka_topic resources of a module don't define the same topic name,
+// as only one of them would win at apply time.
+type MSKTopicUniqueNamesRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicUniqueNamesRule) Name() string {
+	return "msk_topic_unique_names"
+}
+
+func (r *MSKTopicUniqueNamesRule) Enabled() bool {
+	return true
+}
+
+func (r *MSKTopicUniqueNamesRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicUniqueNamesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+func (r *MSKTopicUniqueNamesRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	topics, _, err := getKafkaTopicNames(runner)
+	if err != nil {
+		return err
+	}
+	// the files are not returned in a stable order, so the first definition is the first one by file name
+	slices.SortStableFunc(topics, func(a, b kafkaTopicName) int {
+		return cmp.Or(
+			strings.Compare(a.defRange.Filename, b.defRange.Filename),
+			a.defRange.Start.Byte-b.defRange.Start.Byte,
+		)
+	})
+
+	// topic_name -> resource_name of its first definition
+	seenNames := map[string]string{}
+	for _, topic := range topics {
+		firstResourceName, ok := seenNames[topic.name]
+		if !ok {
+			seenNames[topic.name] = topic.resourceName
+			continue
+		}
+
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"topic name must be unique across a module, but '%s' is already defined by kafka_topic '%s'",
+				topic.name,
+				firstResourceName,
+			),
+			topic.attr.Range,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: duplicate topic name: %w", err)
+		}
+	}
+
+	return nil
+}
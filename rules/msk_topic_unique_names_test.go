@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicUniqueNamesRule(t *testing.T) {
+	rule := &MSKTopicUniqueNamesRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "reports duplicate topic names in same file",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "orders_copy" {
+  name = "pubsub.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic name must be unique across a module, but 'pubsub.orders' is already defined by kafka_topic 'orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "reports repeated duplicate topic names",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "orders_copy" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "orders_other_copy" {
+  name = "pubsub.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic name must be unique across a module, but 'pubsub.orders' is already defined by kafka_topic 'orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 25},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "topic name must be unique across a module, but 'pubsub.orders' is already defined by kafka_topic 'orders'",
+					Range: hcl.Range{
+						Filename: "topics.tf",
+						Start:    hcl.Pos{Line: 11, Column: 3},
+						End:      hcl.Pos{Line: 11, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "reports duplicate topic names across files",
+			files: map[string]string{
+				"a_topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+`,
+				"b_topics.tf": `
+resource "kafka_topic" "orders_copy" {
+  name = "pubsub.orders"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic name must be unique across a module, but 'pubsub.orders' is already defined by kafka_topic 'orders'",
+					Range: hcl.Range{
+						Filename: "b_topics.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			name: "ignores topics without a name",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "nameless" {
+  replication_factor = 3
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "unique topic names",
+			files: map[string]string{
+				"topics.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "payments" {
+  name = "pubsub.payments"
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
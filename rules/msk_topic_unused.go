@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// MSKTopicUnusedRule checks whether the topics defined in a module are produced or consumed by an app of the module.
+type MSKTopicUnusedRule struct {
+	tflint.DefaultRule
+}
+
+func (r *MSKTopicUnusedRule) Name() string {
+	return "msk_topic_unused"
+}
+
+func (r *MSKTopicUnusedRule) Enabled() bool {
+	return false
+}
+
+func (r *MSKTopicUnusedRule) Link() string {
+	return ReferenceLink(r.Name())
+}
+
+func (r *MSKTopicUnusedRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+func (r *MSKTopicUnusedRule) Check(runner tflint.Runner) error {
+	isRoot, err := isRootModule(runner)
+	if err != nil {
+		return err
+	}
+	if !isRoot {
+		logger.Debug("skipping child module")
+		return nil
+	}
+
+	topics, dynamicTopics, err := getKafkaTopicNames(runner)
+	if err != nil {
+		return err
+	}
+	resourceNameMap := make(map[string]string, len(topics))
+	for _, topic := range topics {
+		resourceNameMap[topic.resourceName] = topic.name
+	}
+
+	modules, err := runner.GetModuleContent(
+		&hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "module",
+					LabelNames: []string{"name"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{
+							{Name: "produce_topics"},
+							{Name: "consume_topics"},
+						},
+					},
+				},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("getting modules: %w", err)
+	}
+
+	resourceType, err := topicResourceType(runner)
+	if err != nil {
+		return err
+	}
+	evalCtx := buildTopicNameContext(resourceType, resourceNameMap, dynamicTopics)
+	usedTopics := map[string]struct{}{}
+	for _, block := range modules.Blocks {
+		for _, topicAttrName := range []string{"consume_topics", "produce_topics"} {
+			topicAttr, ok := block.Body.Attributes[topicAttrName]
+			if !ok {
+				continue
+			}
+
+			val, diags := topicAttr.Expr.Value(evalCtx)
+			if diags.HasErrors() {
+				if err := runner.EvaluateExpr(topicAttr.Expr, &val, nil); err != nil {
+					logger.Debug("skipping module with topics which can't be evaluated", "labels", block.Labels, "error", err)
+					return nil
+				}
+			}
+			// a topic used through an unknown value can't be told apart from an unused one
+			if !val.IsWhollyKnown() || !val.CanIterateElements() {
+				logger.Debug("skipping module with topics which can't be statically verified", "labels", block.Labels)
+				return nil
+			}
+			for _, v := range val.AsValueSlice() {
+				if v.Type() == cty.String {
+					usedTopics[v.AsString()] = struct{}{}
+				}
+			}
+		}
+	}
+
+	for _, topic := range topics {
+		if _, ok := usedTopics[topic.name]; ok {
+			continue
+		}
+		err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"topic '%s' is not produced or consumed by any app of the module: remove it if it is a leftover",
+				topic.name,
+			),
+			topic.defRange,
+		)
+		if err != nil {
+			return fmt.Errorf("emitting issue: unused topic: %w", err)
+		}
+	}
+	return nil
+}
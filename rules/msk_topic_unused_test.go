@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_MSKTopicUnusedRule(t *testing.T) {
+	rule := &MSKTopicUnusedRule{}
+
+	for _, tc := range []struct {
+		name     string
+		files    map[string]string
+		expected helper.Issues
+	}{
+		{
+			name: "topic not produced or consumed",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+resource "kafka_topic" "leftover" {
+  name = "pubsub.leftover"
+}
+
+module "producer" {
+  produce_topics = [kafka_topic.orders.name]
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "topic 'pubsub.leftover' is not produced or consumed by any app of the module: remove it if it is a leftover",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			name: "topic consumed",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  name = "pubsub.orders"
+}
+
+module "consumer" {
+  consume_topics = ["pubsub.orders"]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "topics used through values which can't be verified",
+			files: map[string]string{
+				"file.tf": `
+resource "kafka_topic" "orders" {
+  for_each = toset(["a", "b"])
+  name     = "pubsub.orders-${each.value}"
+}
+
+resource "kafka_topic" "payments" {
+  name = "pubsub.payments"
+}
+
+module "consumer" {
+  consume_topics = [kafka_topic.orders["a"].name]
+}
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := helper.TestRunner(t, tc.files)
+			require.NoError(t, rule.Check(runner))
+
+			helper.AssertIssues(t, tc.expected, runner.Issues)
+		})
+	}
+}
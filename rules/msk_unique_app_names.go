@@ -1,7 +1,10 @@
 package rules
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2/gohcl"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
@@ -46,7 +49,21 @@ func (r *MSKUniqueAppNamesRule) Check(runner tflint.Runner) error {
 		return err
 	}
 
-	return r.reportDuplicateTLSAppNames(runner, TLSAppModules)
+	appNames, err := decodeTLSAppNames(TLSAppModules)
+	if err != nil {
+		return err
+	}
+	// the files are not returned in a stable order, so the first definition is the first one by file name
+	slices.SortStableFunc(appNames, func(a, b tlsAppName) int {
+		return cmp.Or(
+			strings.Compare(a.attr.Range.Filename, b.attr.Range.Filename),
+			a.attr.Range.Start.Byte-b.attr.Range.Start.Byte,
+		)
+	})
+	if err := r.reportMalformedTLSAppNames(runner, appNames); err != nil {
+		return err
+	}
+	return r.reportDuplicateTLSAppNames(runner, appNames)
 }
 
 func getTLSAppModules(runner tflint.Runner) (hclext.Blocks, error) {
@@ -81,39 +98,77 @@ func getTLSAppModules(runner tflint.Runner) (hclext.Blocks, error) {
 }
 
 type tlsAppName struct {
-	attr *hclext.Attribute
-	name string
+	attr       *hclext.Attribute
+	name       string
+	moduleName string
 }
 
-func (r *MSKUniqueAppNamesRule) reportDuplicateTLSAppNames(runner tflint.Runner, tlsAppModules hclext.Blocks) error {
-	seenNames := map[string]struct{}{}
-	duplicateNames := []tlsAppName{}
+func decodeTLSAppNames(tlsAppModules hclext.Blocks) ([]tlsAppName, error) {
+	appNames := make([]tlsAppName, 0, len(tlsAppModules))
 	for _, appModule := range tlsAppModules {
 		appNameAttr := appModule.Body.Attributes[commonNameAttribute]
 
 		var appName string
 		diags := gohcl.DecodeExpression(appNameAttr.Expr, nil, &appName)
 		if diags.HasErrors() {
-			return fmt.Errorf("decoding expression for attribute %s: %w", commonNameAttribute, diags)
+			return nil, fmt.Errorf("decoding expression for attribute %s: %w", commonNameAttribute, diags)
 		}
+		appNames = append(appNames, tlsAppName{attr: appNameAttr, name: appName, moduleName: appModule.Labels[0]})
+	}
+	return appNames, nil
+}
 
-		if _, ok := seenNames[appName]; ok {
-			duplicateNames = append(duplicateNames, tlsAppName{attr: appNameAttr, name: appName})
+// reportMalformedTLSAppNames reports the names which don't have the 'namespace/app' format, like 'my-app'.
+func (r *MSKUniqueAppNamesRule) reportMalformedTLSAppNames(runner tflint.Runner, appNames []tlsAppName) error {
+	for _, appName := range appNames {
+		segments := strings.Split(appName.name, certCommonNameSepChar)
+		if len(segments) == 2 && segments[0] != "" && segments[1] != "" {
 			continue
 		}
 
-		seenNames[appName] = struct{}{}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf(
+				"'%s' must have the format 'namespace/app', with non-empty segments separated by a single '%s', but it is '%s'",
+				commonNameAttribute,
+				certCommonNameSepChar,
+				appName.name,
+			),
+			appName.attr.Range,
+		); err != nil {
+			return fmt.Errorf("emitting issue: malformed app name: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *MSKUniqueAppNamesRule) reportDuplicateTLSAppNames(runner tflint.Runner, appNames []tlsAppName) error {
+	seenNames := map[string]struct{}{}
+	duplicateNames := []tlsAppName{}
+	for _, appName := range appNames {
+		if _, ok := seenNames[appName.name]; ok {
+			duplicateNames = append(duplicateNames, appName)
+			continue
+		}
+
+		seenNames[appName.name] = struct{}{}
 	}
 
 	for _, appName := range duplicateNames {
-		if err := runner.EmitIssue(
+		// best-effort fix: the module label is unique, so suffixing the name with it makes it unique as well.
+		fixedName := appName.name + "-" + appName.moduleName
+		if err := runner.EmitIssueWithFix(
 			r,
 			fmt.Sprintf(
-				"'%s' must be unique across a module, but '%s' has already been seen",
+				"'%s' must be unique across a module, but '%s' has already been seen: renaming it to '%s' after the module label as a best-effort fix, adjust it if needed",
 				commonNameAttribute,
 				appName.name,
+				fixedName,
 			),
 			appName.attr.Range,
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(appName.attr.Expr.Range(), fmt.Sprintf("%q", fixedName))
+			},
 		); err != nil {
 			return fmt.Errorf("emitting issue: %w", err)
 		}
@@ -14,6 +14,7 @@ func Test_MSKUniqueAppNamesRule(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
 		files    map[string]string
+		fixed    string
 		expected helper.Issues
 	}{
 		{
@@ -31,10 +32,21 @@ module "second_app" {
 }
 `,
 			},
+			fixed: `
+module "first_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-namespace/my-app"
+}
+
+module "second_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-namespace/my-app-second_app"
+}
+`,
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
-					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen",
+					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen: renaming it to 'my-namespace/my-app-second_app' after the module label as a best-effort fix, adjust it if needed",
 					Range: hcl.Range{
 						Filename: "file.tf",
 						Start:    hcl.Pos{Line: 9, Column: 3},
@@ -66,7 +78,7 @@ module "third_app" {
 			expected: []*helper.Issue{
 				{
 					Rule:    rule,
-					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen",
+					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen: renaming it to 'my-namespace/my-app-second_app' after the module label as a best-effort fix, adjust it if needed",
 					Range: hcl.Range{
 						Filename: "file.tf",
 						Start:    hcl.Pos{Line: 9, Column: 3},
@@ -75,7 +87,7 @@ module "third_app" {
 				},
 				{
 					Rule:    rule,
-					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen",
+					Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen: renaming it to 'my-namespace/my-app-third_app' after the module label as a best-effort fix, adjust it if needed",
 					Range: hcl.Range{
 						Filename: "file.tf",
 						Start:    hcl.Pos{Line: 14, Column: 3},
@@ -84,6 +96,101 @@ module "third_app" {
 				},
 			},
 		},
+		{
+			name: "reports app names without the namespace/app format",
+			files: map[string]string{
+				"file.tf": `
+module "first_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-app"
+}
+
+module "second_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-namespace/second-app/extra"
+}
+
+module "third_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "/third-app"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must have the format 'namespace/app', with non-empty segments separated by a single '/', but it is 'my-app'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 30},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must have the format 'namespace/app', with non-empty segments separated by a single '/', but it is 'my-namespace/second-app/extra'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 53},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must have the format 'namespace/app', with non-empty segments separated by a single '/', but it is '/third-app'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 14, Column: 3},
+						End:      hcl.Pos{Line: 14, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			name: "reports malformed duplicate app names with both issues",
+			files: map[string]string{
+				"file.tf": `
+module "first_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-app"
+}
+
+module "second_app" {
+  source           = "../../../modules/tls-app"
+  cert_common_name = "my-app"
+}
+`,
+			},
+			expected: []*helper.Issue{
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must have the format 'namespace/app', with non-empty segments separated by a single '/', but it is 'my-app'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 30},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must have the format 'namespace/app', with non-empty segments separated by a single '/', but it is 'my-app'",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 30},
+					},
+				},
+				{
+					Rule:    rule,
+					Message: "'cert_common_name' must be unique across a module, but 'my-app' has already been seen: renaming it to 'my-app-second_app' after the module label as a best-effort fix, adjust it if needed",
+					Range: hcl.Range{
+						Filename: "file.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 30},
+					},
+				},
+			},
+		},
 		{
 			name: "Reports nothing with all unique names",
 			files: map[string]string{
@@ -97,6 +204,21 @@ module "second_app" {
   source           = "../../../modules/tls-app"
   cert_common_name = "my-namespace/second-app"
 }
+`,
+			},
+			expected: []*helper.Issue{},
+		},
+		{
+			name: "Reports nothing without any modules",
+			files: map[string]string{
+				"backend.tf": `
+terraform {
+  backend "s3" {
+    bucket = "uw-dev-pubsub-msk-tf-state"
+    key    = "dev-aws/kafka-shared-msk/pubsub/tfstate"
+    region = "eu-west-1"
+  }
+}
 `,
 			},
 			expected: []*helper.Issue{},
@@ -108,6 +230,9 @@ module "second_app" {
 			require.NoError(t, rule.Check(runner))
 
 			helper.AssertIssues(t, tc.expected, runner.Issues)
+			if tc.fixed != "" {
+				helper.AssertChanges(t, map[string]string{"file.tf": tc.fixed}, runner.Changes())
+			}
 		})
 	}
 
@@ -132,7 +257,7 @@ module "second_app" {
 		expectedIssues := []*helper.Issue{
 			{
 				Rule:    rule,
-				Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen",
+				Message: "'cert_common_name' must be unique across a module, but 'my-namespace/my-app' has already been seen: renaming it to 'my-namespace/my-app-second_app' after the module label as a best-effort fix, adjust it if needed",
 			},
 		}
 
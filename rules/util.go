@@ -2,7 +2,11 @@ package rules
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
@@ -21,3 +25,112 @@ func isRootModule(runner tflint.Runner) (bool, error) {
 
 	return path.IsRoot(), nil
 }
+
+// getModulePath returns the path of the module, from which several rules derive the team or the env.
+// Some execution contexts, like older tflint versions, can't provide it: ok is then false and the caller skips its
+// path-derived checks silently, as the msk_module_backend rule notices it once for the whole module.
+func getModulePath(runner tflint.Runner) (string, bool) {
+	modulePath, err := runner.GetOriginalwd()
+	if err != nil {
+		logger.Debug("failed getting module path", "err", err)
+		return "", false
+	}
+	return modulePath, true
+}
+
+const minModulePathElems = 3
+
+// splitModulePath returns the elements of the module path.
+// The leading separator of an absolute path is trimmed, so '/cluster/team' doesn't count an empty env element.
+func splitModulePath(modulePath string) []string {
+	sep := string(filepath.Separator)
+	return strings.Split(strings.TrimPrefix(filepath.Clean(modulePath), sep), sep)
+}
+
+// The team modules are expected to live in a path ending with
+// '${env}-${platform}/${msk-cluster}/${team-name}'.
+// envFromModulePath returns the env part, for example 'prod' for 'prod-aws'.
+func envFromModulePath(modulePath string) (string, bool) {
+	pathElems := splitModulePath(modulePath)
+	if len(pathElems) < minModulePathElems {
+		return "", false
+	}
+
+	envPlatform := pathElems[len(pathElems)-minModulePathElems]
+	env, _, _ := strings.Cut(envPlatform, "-")
+	return env, true
+}
+
+// Several rules inspect and fix the native HCL syntax (comments, object
+// literals), which is not available for Terraform files in JSON syntax.
+func isJSONSyntax(rng hcl.Range) bool {
+	return strings.HasSuffix(rng.Filename, ".json")
+}
+
+const defaultTopicResourceType = "kafka_topic"
+
+// topicResourceType returns the resource type of the topics, which can be renamed by a provider fork or alias.
+// Like the team aliases, it is configured on the msk_topic_name rule and used by all the topic rules.
+func topicResourceType(runner tflint.Runner) (string, error) {
+	var topicNameConfig mskTopicNameRuleConfig
+	if err := runner.DecodeRuleConfig((&MSKTopicNameRule{}).Name(), &topicNameConfig); err != nil {
+		return "", fmt.Errorf("decoding rule config: %w", err)
+	}
+	if topicNameConfig.TopicResourceType == "" {
+		return defaultTopicResourceType, nil
+	}
+	return topicNameConfig.TopicResourceType, nil
+}
+
+// issueCategory tags an issue with the concern it addresses, so downstream tooling, like the SARIF viewers of the
+// editors, can group the issues beyond the rule which emitted them.
+type issueCategory string
+
+const (
+	categoryCorrectness issueCategory = "correctness"
+	categoryDurability  issueCategory = "durability"
+	categoryCost        issueCategory = "cost"
+	categoryNaming      issueCategory = "naming"
+)
+
+// categorizedMessage prepends the '[category]' token to the message of an issue.
+func categorizedMessage(category issueCategory, message string) string {
+	return fmt.Sprintf("[%s] %s", category, message)
+}
+
+// emitCategorizedIssue emits an issue with its message prefixed by the category.
+func emitCategorizedIssue(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	category issueCategory,
+	message string,
+	issueRange hcl.Range,
+) error {
+	return runner.EmitIssue(rule, categorizedMessage(category, message), issueRange)
+}
+
+// emitCategorizedIssueWithFix emits an issue with a fix and its message prefixed by the category.
+func emitCategorizedIssueWithFix(
+	runner tflint.Runner,
+	rule tflint.Rule,
+	category issueCategory,
+	message string,
+	issueRange hcl.Range,
+	fixFunc func(f tflint.Fixer) error,
+) error {
+	return runner.EmitIssueWithFix(rule, categorizedMessage(category, message), issueRange, fixFunc)
+}
+
+// severityRule overrides the severity of the issues emitted for a rule.
+type severityRule struct {
+	tflint.Rule
+	severity tflint.Severity
+}
+
+func (r *severityRule) Severity() tflint.Severity {
+	return r.severity
+}
+
+func withSeverity(rule tflint.Rule, severity tflint.Severity) tflint.Rule {
+	return &severityRule{Rule: rule, severity: severity}
+}